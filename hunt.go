@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/w-h-a/scraper/internal/clients/readwriter"
+	"github.com/w-h-a/scraper/internal/clients/readwriter/dryrun"
+	"github.com/w-h-a/scraper/internal/config"
+	"github.com/w-h-a/scraper/internal/services/jobhunter"
+)
+
+// Exit codes of the hunt command, so a CronJob can tell outcomes apart.
+const (
+	exitSuccess = 0
+	exitFailure = 1
+	exitPartial = 2
+	exitUsage   = 64
+)
+
+// hunt runs a single job hunt and returns the exit code for its outcome.
+// Setup failures, including panics from misconfiguration, count as failure
+// rather than surfacing as the runtime's own exit code 2.
+func hunt(args []string) (code int) {
+	fs := flag.NewFlagSet("hunt", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+
+	once := fs.Bool("once", false, "run a single job hunt and exit")
+	dryRun := fs.Bool("dry-run", false, "print the rows a single job hunt would write instead of writing them; implies --once")
+	format := fs.String("format", string(jobhunter.ReportTable), "dry-run output format: table, json or csv")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitSuccess
+		}
+		return exitUsage
+	}
+
+	reportFormat, ok := jobhunter.ReportFormats[*format]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unsupported format %q\n\n", *format)
+		fs.Usage()
+		return exitUsage
+	}
+
+	if !*once && !*dryRun {
+		fmt.Fprint(os.Stderr, "hunt needs --once; use run for the scheduled service\n\n"+usage)
+		return exitUsage
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "job hunt aborted: %v\n", r)
+			code = exitFailure
+		}
+	}()
+
+	ctx := context.Background()
+
+	config.New()
+
+	// a dry run owns stdout for its report
+	logOut := io.Writer(os.Stdout)
+	if *dryRun {
+		logOut = os.Stderr
+	}
+
+	tel, err := initTelemetry(ctx, logOut)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to set up telemetry: %v\n", err)
+		return exitFailure
+	}
+	defer tel.shutdown(ctx)
+
+	canonicalizer, err := initCanonicalizer(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load canonical rules", "error", err)
+		return exitFailure
+	}
+
+	// a dry run reads the store as it is, without migrating its schema
+	var rwOpts []readwriter.Option
+	if *dryRun {
+		rwOpts = append(rwOpts, readwriter.WithoutMigrations())
+	}
+
+	rw, err := initReadWriter(ctx, rwOpts...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to set up readwriter", "error", err)
+		return exitFailure
+	}
+
+	var recorder interface{ RowsWritten() [][]any }
+
+	if *dryRun {
+		dryRunRW := dryrun.NewReadWriter(dryrun.WithReadWriter(rw))
+		recorder, rw = dryRunRW, dryRunRW
+	}
+
+	hunter, err := initHunter(ctx, rw, canonicalizer, *dryRun)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to set up job hunter", "error", err)
+		return exitFailure
+	}
+
+	// the outcome below carries the error, which Hunt has already logged
+	_ = hunter.Hunt(ctx)
+
+	cycle := hunter.Status().LastCycle
+
+	code = exitCode(cycle)
+
+	if recorder != nil {
+		var sources []jobhunter.SourceStatus
+		if cycle != nil {
+			sources = cycle.Sources
+		}
+
+		if err := jobhunter.WriteReport(os.Stdout, os.Stderr, reportFormat, recorder.RowsWritten(), sources); err != nil {
+			slog.ErrorContext(ctx, "failed to print dry run report", "error", err)
+			code = exitFailure
+		}
+	}
+
+	slog.InfoContext(ctx, "job hunt finished", "exit_code", code)
+
+	return code
+}
+
+func exitCode(cycle *jobhunter.CycleStatus) int {
+	if cycle == nil {
+		return exitFailure
+	}
+
+	switch cycle.Outcome {
+	case jobhunter.OutcomeSuccess:
+		return exitSuccess
+	case jobhunter.OutcomePartial:
+		return exitPartial
+	default:
+		return exitFailure
+	}
+}
@@ -0,0 +1,205 @@
+// Package canonical rewrites job links into one canonical form, so that the
+// same posting reached through different tracking links dedups to one row.
+package canonical
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// trackingParams are dropped from every link. Entries ending in "_" are prefixes.
+var trackingParams = []string{
+	"utm_",
+	"ref",
+	"ref_src",
+	"referrer",
+	"fbclid",
+	"gclid",
+	"mc_cid",
+	"mc_eid",
+	"_hsenc",
+	"_hsmi",
+	"trk",
+	"gh_src",
+	"lever-source",
+	"lever-origin",
+}
+
+// Rule adjusts links on one host and its subdomains.
+type Rule struct {
+	Host string
+	// Path, when it matches, replaces the whole path with Replace expanded
+	// against the match, e.g. `/jobs/(\d+)` and `/jobs/$1` keep only the
+	// job ID segment.
+	Path    *regexp.Regexp
+	Replace string
+	// KeepQuery, when set, lists the only query parameters that survive.
+	KeepQuery []string
+	// StripQuery drops the whole query.
+	StripQuery bool
+}
+
+type Canonicalizer struct {
+	options Options
+	drop    map[string]bool
+	prefix  []string
+}
+
+// Canonicalize returns the canonical form of raw: https, lower-case host
+// without default port, no fragment, no tracking parameters, sorted query
+// and no trailing slash, followed by the host's rule. Anything that is not
+// an absolute http(s) URL is returned trimmed but otherwise unchanged.
+func (c *Canonicalizer) Canonicalize(raw string) string {
+	raw = strings.TrimSpace(raw)
+
+	u, err := url.Parse(raw)
+	if err != nil || len(u.Host) == 0 {
+		return raw
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return raw
+	}
+
+	u.Scheme = "https"
+	u.Host = canonicalHost(u.Host)
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	rule, hasRule := c.ruleFor(u.Hostname())
+
+	path := u.Path
+
+	if hasRule && rule.Path != nil {
+		if match := rule.Path.FindStringSubmatchIndex(path); match != nil {
+			path = string(rule.Path.ExpandString(nil, rule.Replace, path, match))
+		}
+	}
+
+	u.Path = strings.TrimRight(path, "/")
+	u.RawPath = ""
+
+	u.RawQuery = c.canonicalQuery(u.Query(), rule, hasRule)
+	u.ForceQuery = false
+
+	return u.String()
+}
+
+// Links returns the canonical forms of a set of links, keeping the originals
+// too so that exact matches still count.
+func (c *Canonicalizer) Links(links map[string]bool) map[string]bool {
+	canonical := make(map[string]bool, len(links))
+
+	for link, ok := range links {
+		if !ok {
+			continue
+		}
+		canonical[link] = true
+		canonical[c.Canonicalize(link)] = true
+	}
+
+	return canonical
+}
+
+func (c *Canonicalizer) canonicalQuery(query url.Values, rule Rule, hasRule bool) string {
+	if hasRule && rule.StripQuery {
+		return ""
+	}
+
+	var keep map[string]bool
+	if hasRule && len(rule.KeepQuery) > 0 {
+		keep = map[string]bool{}
+		for _, param := range rule.KeepQuery {
+			keep[param] = true
+		}
+	}
+
+	for param := range query {
+		if keep != nil && !keep[param] {
+			query.Del(param)
+			continue
+		}
+		if c.isTracking(param) {
+			query.Del(param)
+		}
+	}
+
+	// Encode sorts by key
+	return query.Encode()
+}
+
+func (c *Canonicalizer) isTracking(param string) bool {
+	param = strings.ToLower(param)
+
+	if c.drop[param] {
+		return true
+	}
+
+	for _, prefix := range c.prefix {
+		if strings.HasPrefix(param, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Canonicalizer) ruleFor(host string) (Rule, bool) {
+	var best Rule
+	found := false
+
+	for _, rule := range c.options.Rules {
+		if host != rule.Host && !strings.HasSuffix(host, "."+rule.Host) {
+			continue
+		}
+		if !found || len(rule.Host) > len(best.Host) {
+			best, found = rule, true
+		}
+	}
+
+	return best, found
+}
+
+func canonicalHost(host string) string {
+	host = strings.ToLower(host)
+
+	if h, port, err := net.SplitHostPort(host); err == nil && (port == "80" || port == "443") {
+		host = h
+		if strings.Contains(h, ":") {
+			host = "[" + h + "]"
+		}
+	}
+
+	return strings.TrimSuffix(host, ".")
+}
+
+func New(opts ...Option) *Canonicalizer {
+	options := NewOptions(opts...)
+
+	c := &Canonicalizer{
+		options: options,
+		drop:    map[string]bool{},
+	}
+
+	for _, param := range append(append([]string{}, trackingParams...), options.DropQuery...) {
+		param = strings.ToLower(strings.TrimSpace(param))
+		if len(param) == 0 {
+			continue
+		}
+		if strings.HasSuffix(param, "_") {
+			c.prefix = append(c.prefix, param)
+			continue
+		}
+		c.drop[param] = true
+	}
+
+	for i, rule := range c.options.Rules {
+		c.options.Rules[i].Host = strings.ToLower(strings.TrimSpace(rule.Host))
+	}
+
+	return c
+}
@@ -0,0 +1,34 @@
+package canonical
+
+type Option func(*Options)
+
+type Options struct {
+	// DropQuery names extra query parameters removed on every host, on top
+	// of the built-in tracking parameters.
+	DropQuery []string
+	Rules     []Rule
+}
+
+func WithDropQuery(params ...string) Option {
+	return func(o *Options) {
+		o.DropQuery = append(o.DropQuery, params...)
+	}
+}
+
+// WithRules adds per-host rules. When several rules match a host, the one
+// with the longest host wins.
+func WithRules(rules ...Rule) Option {
+	return func(o *Options) {
+		o.Rules = append(o.Rules, rules...)
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
@@ -0,0 +1,91 @@
+package canonical
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type rulesFile struct {
+	DropQuery []string    `yaml:"drop_query"`
+	Hosts     []hostEntry `yaml:"hosts"`
+}
+
+type hostEntry struct {
+	Host       string   `yaml:"host"`
+	Path       string   `yaml:"path"`
+	Replace    string   `yaml:"replace"`
+	KeepQuery  []string `yaml:"keep_query"`
+	StripQuery bool     `yaml:"strip_query"`
+}
+
+// LoadRules reads canonicalization rules from a YAML or JSON file.
+func LoadRules(path string) ([]Option, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read canonical rules file at %s: %w", path, err)
+	}
+
+	return ParseRules(data)
+}
+
+// ParseRules decodes canonicalization rules into options for New.
+func ParseRules(data []byte) ([]Option, error) {
+	var file rulesFile
+
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse canonical rules: %w", err)
+	}
+
+	var errs []error
+
+	rules := make([]Rule, 0, len(file.Hosts))
+
+	for i, entry := range file.Hosts {
+		host := strings.TrimSpace(entry.Host)
+		if len(host) == 0 {
+			errs = append(errs, fmt.Errorf("host rule %d: host is required", i))
+			continue
+		}
+
+		rule := Rule{
+			Host:       host,
+			Replace:    entry.Replace,
+			KeepQuery:  entry.KeepQuery,
+			StripQuery: entry.StripQuery,
+		}
+
+		if len(entry.Path) > 0 {
+			re, err := regexp.Compile(entry.Path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("host rule %q: invalid path regex %q: %w", host, entry.Path, err))
+				continue
+			}
+			if len(entry.Replace) == 0 {
+				errs = append(errs, fmt.Errorf("host rule %q: path needs replace", host))
+				continue
+			}
+			rule.Path = re
+		}
+
+		if rule.StripQuery && len(rule.KeepQuery) > 0 {
+			errs = append(errs, fmt.Errorf("host rule %q: keep_query and strip_query are exclusive", host))
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return []Option{
+		WithDropQuery(file.DropQuery...),
+		WithRules(rules...),
+	}, nil
+}
@@ -0,0 +1,16 @@
+package cache
+
+import "context"
+
+type CacheType string
+
+const (
+	Memory CacheType = "memory"
+	File   CacheType = "file"
+)
+
+// Cache is a small key/value store for state that should outlive one cycle.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+}
@@ -0,0 +1,128 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/w-h-a/scraper/internal/clients/cache"
+)
+
+func init() {
+	cache.Register(cache.File, NewCache)
+}
+
+const defaultPath = "cache.json"
+
+// fileCache keeps every entry in one JSON document. The document is read on
+// first use and rewritten through a temp file on every Set, so a crash never
+// leaves a half-written cache behind.
+type fileCache struct {
+	options cache.Options
+	path    string
+	entries map[string][]byte
+	loaded  bool
+	mtx     sync.Mutex
+}
+
+func (c *fileCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.load(); err != nil {
+		return nil, false, err
+	}
+
+	value, ok := c.entries[key]
+
+	return value, ok, nil
+}
+
+func (c *fileCache) Set(_ context.Context, key string, value []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.load(); err != nil {
+		return err
+	}
+
+	c.entries[key] = value
+
+	return c.flush()
+}
+
+func (c *fileCache) load() error {
+	if c.loaded {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		c.loaded = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read cache at %s: %w", c.path, err)
+	}
+
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return fmt.Errorf("failed to parse cache at %s: %w", c.path, err)
+	}
+
+	if c.entries == nil {
+		c.entries = map[string][]byte{}
+	}
+
+	c.loaded = true
+
+	return nil
+}
+
+func (c *fileCache) flush() error {
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache at %s: %w", c.path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache at %s: %w", c.path, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache at %s: %w", c.path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write cache at %s: %w", c.path, err)
+	}
+
+	return nil
+}
+
+func NewCache(opts ...cache.Option) cache.Cache {
+	options := cache.NewOptions(opts...)
+
+	c := &fileCache{
+		options: options,
+		path:    defaultPath,
+		entries: map[string][]byte{},
+	}
+
+	if len(options.Location) > 0 {
+		c.path = options.Location
+	}
+
+	return c
+}
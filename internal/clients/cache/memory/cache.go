@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/w-h-a/scraper/internal/clients/cache"
+)
+
+func init() {
+	cache.Register(cache.Memory, NewCache)
+}
+
+type memoryCache struct {
+	options cache.Options
+	entries map[string][]byte
+	mtx     sync.RWMutex
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	value, ok := c.entries[key]
+
+	return value, ok, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.entries[key] = value
+
+	return nil
+}
+
+func NewCache(opts ...cache.Option) cache.Cache {
+	options := cache.NewOptions(opts...)
+
+	return &memoryCache{
+		options: options,
+		entries: map[string][]byte{},
+	}
+}
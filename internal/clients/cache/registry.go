@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+)
+
+type Factory func(opts ...Option) Cache
+
+var (
+	factories = map[CacheType]Factory{}
+	mtx       sync.RWMutex
+)
+
+// Register makes a cache implementation available to New under the given type.
+// Implementations call it from an init function.
+func Register(t CacheType, factory Factory) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if _, ok := factories[t]; ok {
+		panic(fmt.Sprintf("cache %q already registered", t))
+	}
+
+	factories[t] = factory
+}
+
+// Registered reports whether an implementation is registered under the given type.
+func Registered(t CacheType) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	_, ok := factories[t]
+
+	return ok
+}
+
+// New builds the cache registered under the given type.
+func New(t CacheType, opts ...Option) (Cache, error) {
+	mtx.RLock()
+	factory, ok := factories[t]
+	mtx.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no cache registered for type %q", t)
+	}
+
+	return factory(opts...), nil
+}
@@ -0,0 +1,37 @@
+package enricher
+
+import (
+	"context"
+	"errors"
+)
+
+type EnricherType string
+
+const (
+	Mock   EnricherType = "mock"
+	JSONLD EnricherType = "jsonld"
+)
+
+// ErrNoPosting is returned when a page has no structured job posting.
+var ErrNoPosting = errors.New("no job posting found")
+
+// Posting holds the structured fields found on a job detail page.
+type Posting struct {
+	HiringOrganization string
+	EmploymentType     string
+	SalaryMin          float64
+	SalaryMax          float64
+	SalaryCurrency     string
+	SalaryPeriod       string
+	JobLocationType    string
+	// JobLocation is the address of the workplace, e.g. "Berlin, DE".
+	JobLocation string
+	// ApplicantLocationRequirements names the places candidates must be in.
+	ApplicantLocationRequirements string
+	ValidThrough                  string
+	Description                   string
+}
+
+type Enricher interface {
+	Enrich(ctx context.Context, url string, opts ...EnrichOption) (*Posting, error)
+}
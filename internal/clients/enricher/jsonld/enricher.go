@@ -0,0 +1,268 @@
+package jsonld
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/w-h-a/scraper/internal/clients/enricher"
+	"github.com/w-h-a/scraper/internal/clients/retry"
+	"golang.org/x/net/html"
+)
+
+func init() {
+	enricher.Register(enricher.JSONLD, NewEnricher)
+}
+
+// pages larger than this are cut off before parsing
+const maxPageBytes = 5 << 20
+
+type jsonldEnricher struct {
+	options enricher.Options
+	client  *http.Client
+}
+
+// Enrich fetches the page at url and reads the first schema.org JobPosting
+// found in its application/ld+json script blocks.
+func (e *jsonldEnricher) Enrich(ctx context.Context, url string, _ ...enricher.EnrichOption) (*enricher.Posting, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/html")
+
+	rsp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return nil, fmt.Errorf("http error: %s", rsp.Status)
+	}
+
+	blocks, err := scripts(io.LimitReader(rsp.Body, maxPageBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	for _, block := range blocks {
+		var doc any
+		if err := json.Unmarshal([]byte(block), &doc); err != nil {
+			// pages often carry malformed blocks next to valid ones
+			continue
+		}
+
+		if node, ok := findJobPosting(doc); ok {
+			return toPosting(node), nil
+		}
+	}
+
+	return nil, enricher.ErrNoPosting
+}
+
+// scripts returns the contents of every application/ld+json script element.
+func scripts(r io.Reader) ([]string, error) {
+	var blocks []string
+
+	tokenizer := html.NewTokenizer(r)
+	inJSONLD := false
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if tokenizer.Err() == io.EOF {
+				return blocks, nil
+			}
+			return blocks, tokenizer.Err()
+		case html.StartTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if string(name) != "script" || !hasAttr {
+				continue
+			}
+			for {
+				key, val, more := tokenizer.TagAttr()
+				if string(key) == "type" && strings.EqualFold(strings.TrimSpace(string(val)), "application/ld+json") {
+					inJSONLD = true
+				}
+				if !more {
+					break
+				}
+			}
+		case html.TextToken:
+			if inJSONLD {
+				blocks = append(blocks, string(tokenizer.Text()))
+			}
+		case html.EndTagToken:
+			inJSONLD = false
+		}
+	}
+}
+
+func findJobPosting(doc any) (map[string]any, bool) {
+	switch v := doc.(type) {
+	case []any:
+		for _, elem := range v {
+			if node, ok := findJobPosting(elem); ok {
+				return node, true
+			}
+		}
+	case map[string]any:
+		if isJobPosting(v["@type"]) {
+			return v, true
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findJobPosting(graph)
+		}
+	}
+
+	return nil, false
+}
+
+func isJobPosting(t any) bool {
+	switch v := t.(type) {
+	case string:
+		return v == "JobPosting"
+	case []any:
+		for _, elem := range v {
+			if s, ok := elem.(string); ok && s == "JobPosting" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func toPosting(node map[string]any) *enricher.Posting {
+	posting := &enricher.Posting{
+		HiringOrganization: name(node["hiringOrganization"]),
+		EmploymentType:     text(node["employmentType"]),
+		JobLocationType:    text(node["jobLocationType"]),
+		ValidThrough:       text(node["validThrough"]),
+		Description:        text(node["description"]),
+
+		JobLocation:                   places(node["jobLocation"], address),
+		ApplicantLocationRequirements: places(node["applicantLocationRequirements"], name),
+	}
+
+	if salary, ok := node["baseSalary"].(map[string]any); ok {
+		posting.SalaryCurrency = text(salary["currency"])
+		posting.SalaryPeriod = text(salary["unitText"])
+
+		switch value := salary["value"].(type) {
+		case map[string]any:
+			posting.SalaryMin = number(value["minValue"])
+			posting.SalaryMax = number(value["maxValue"])
+			if exact := number(value["value"]); exact > 0 {
+				posting.SalaryMin, posting.SalaryMax = exact, exact
+			}
+			if unit := text(value["unitText"]); len(unit) > 0 {
+				posting.SalaryPeriod = unit
+			}
+		default:
+			exact := number(value)
+			posting.SalaryMin, posting.SalaryMax = exact, exact
+		}
+
+		if posting.SalaryMax < posting.SalaryMin {
+			posting.SalaryMax = posting.SalaryMin
+		}
+	}
+
+	return posting
+}
+
+// places reads a Place or a list of them, joining each with "; ".
+func places(v any, read func(any) string) string {
+	list, ok := v.([]any)
+	if !ok {
+		return read(v)
+	}
+
+	var parts []string
+	for _, elem := range list {
+		if s := read(elem); len(s) > 0 {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// address reads the PostalAddress of a Place as "locality, region, country".
+func address(v any) string {
+	place, ok := v.(map[string]any)
+	if !ok {
+		return text(v)
+	}
+
+	addr, ok := place["address"].(map[string]any)
+	if !ok {
+		return name(place)
+	}
+
+	var parts []string
+	for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+		if s := name(addr[key]); len(s) > 0 {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// name reads an Organization style value, which may be a plain string.
+func name(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		return text(m["name"])
+	}
+	return text(v)
+}
+
+func text(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case []any:
+		var parts []string
+		for _, elem := range t {
+			if s := text(elem); len(s) > 0 {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	return ""
+}
+
+func number(v any) float64 {
+	switch t := v.(type) {
+	case float64:
+		return t
+	case string:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(t), ",", ""), 64)
+		if err == nil {
+			return f
+		}
+	}
+
+	return 0
+}
+
+func NewEnricher(opts ...enricher.Option) enricher.Enricher {
+	options := enricher.NewOptions(opts...)
+
+	e := &jsonldEnricher{
+		options: options,
+		client:  &http.Client{Transport: retry.NewTransport(nil, options.Retry...)},
+	}
+
+	return e
+}
@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/w-h-a/scraper/internal/clients/enricher"
+)
+
+func init() {
+	enricher.Register(enricher.Mock, func(opts ...enricher.Option) enricher.Enricher {
+		return NewEnricher(opts...)
+	})
+}
+
+type mockEnricher struct {
+	options          enricher.Options
+	postingsToReturn map[string]*enricher.Posting
+	errToReturn      error
+	requested        []string
+	mtx              sync.Mutex
+}
+
+func (e *mockEnricher) Enrich(_ context.Context, url string, _ ...enricher.EnrichOption) (*enricher.Posting, error) {
+	e.mtx.Lock()
+	e.requested = append(e.requested, url)
+	e.mtx.Unlock()
+
+	if e.errToReturn != nil {
+		return nil, e.errToReturn
+	}
+
+	if posting, ok := e.postingsToReturn[url]; ok {
+		return posting, nil
+	}
+
+	return &enricher.Posting{}, nil
+}
+
+// Requested returns the urls enriched so far, sorted.
+func (e *mockEnricher) Requested() []string {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	urls := slices.Clone(e.requested)
+	slices.Sort(urls)
+
+	return urls
+}
+
+func NewEnricher(opts ...enricher.Option) *mockEnricher {
+	options := enricher.NewOptions(opts...)
+
+	e := &mockEnricher{
+		options: options,
+	}
+
+	if postings, ok := getPostingsFromCtx(options.Context); ok {
+		e.postingsToReturn = postings
+	}
+
+	if err, ok := getErrFromCtx(options.Context); ok {
+		e.errToReturn = err
+	}
+
+	return e
+}
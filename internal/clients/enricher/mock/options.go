@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/w-h-a/scraper/internal/clients/enricher"
+)
+
+type postingsKey struct{}
+type errKey struct{}
+
+// WithPostings sets the posting returned for each url.
+func WithPostings(postings map[string]*enricher.Posting) enricher.Option {
+	return func(o *enricher.Options) {
+		o.Context = context.WithValue(o.Context, postingsKey{}, postings)
+	}
+}
+
+func getPostingsFromCtx(ctx context.Context) (map[string]*enricher.Posting, bool) {
+	postings, ok := ctx.Value(postingsKey{}).(map[string]*enricher.Posting)
+	return postings, ok
+}
+
+func WithErr(err error) enricher.Option {
+	return func(o *enricher.Options) {
+		o.Context = context.WithValue(o.Context, errKey{}, err)
+	}
+}
+
+func getErrFromCtx(ctx context.Context) (error, bool) {
+	err, ok := ctx.Value(errKey{}).(error)
+	return err, ok
+}
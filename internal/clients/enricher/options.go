@@ -0,0 +1,51 @@
+package enricher
+
+import (
+	"context"
+
+	"github.com/w-h-a/scraper/internal/clients/retry"
+)
+
+type Option func(*Options)
+
+type Options struct {
+	Retry   []retry.Option
+	Context context.Context
+}
+
+// WithRetry tunes how HTTP-backed enrichers retry transient failures.
+func WithRetry(opts ...retry.Option) Option {
+	return func(o *Options) {
+		o.Retry = append(o.Retry, opts...)
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		Context: context.Background(),
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
+
+type EnrichOption func(*EnrichOptions)
+
+type EnrichOptions struct {
+	Context context.Context
+}
+
+func NewEnrichOptions(opts ...EnrichOption) EnrichOptions {
+	options := EnrichOptions{
+		Context: context.Background(),
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
@@ -0,0 +1,49 @@
+package enricher
+
+import (
+	"fmt"
+	"sync"
+)
+
+type Factory func(opts ...Option) Enricher
+
+var (
+	factories = map[EnricherType]Factory{}
+	mtx       sync.RWMutex
+)
+
+// Register makes an enricher implementation available to New under the given type.
+// Implementations call it from an init function.
+func Register(t EnricherType, factory Factory) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if _, ok := factories[t]; ok {
+		panic(fmt.Sprintf("enricher %q already registered", t))
+	}
+
+	factories[t] = factory
+}
+
+// Registered reports whether an implementation is registered under the given type.
+func Registered(t EnricherType) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	_, ok := factories[t]
+
+	return ok
+}
+
+// New builds the enricher registered under the given type.
+func New(t EnricherType, opts ...Option) (Enricher, error) {
+	mtx.RLock()
+	factory, ok := factories[t]
+	mtx.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no enricher registered for type %q", t)
+	}
+
+	return factory(opts...), nil
+}
@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/w-h-a/scraper/internal/clients/notifier"
+)
+
+func init() {
+	notifier.Register(notifier.Mock, func(opts ...notifier.Option) notifier.Notifier {
+		return NewNotifier(opts...)
+	})
+}
+
+type mockNotifier struct {
+	options     notifier.Options
+	Batches     [][]notifier.Notification
+	errToReturn error
+	mtx         sync.Mutex
+}
+
+func (n *mockNotifier) Notify(_ context.Context, notifications []notifier.Notification, _ ...notifier.NotifyOption) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	n.Batches = append(n.Batches, notifications)
+
+	return n.errToReturn
+}
+
+func NewNotifier(opts ...notifier.Option) *mockNotifier {
+	options := notifier.NewOptions(opts...)
+
+	n := &mockNotifier{
+		options: options,
+	}
+
+	if err, ok := getErrFromCtx(options.Context); ok {
+		n.errToReturn = err
+	}
+
+	return n
+}
@@ -0,0 +1,20 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/w-h-a/scraper/internal/clients/notifier"
+)
+
+type errKey struct{}
+
+func WithErr(err error) notifier.Option {
+	return func(o *notifier.Options) {
+		o.Context = context.WithValue(o.Context, errKey{}, err)
+	}
+}
+
+func getErrFromCtx(ctx context.Context) (error, bool) {
+	err, ok := ctx.Value(errKey{}).(error)
+	return err, ok
+}
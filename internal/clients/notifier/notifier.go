@@ -0,0 +1,23 @@
+package notifier
+
+import "context"
+
+type NotifierType string
+
+const (
+	Mock  NotifierType = "mock"
+	Slack NotifierType = "slack"
+)
+
+// Notification describes one newly found job.
+type Notification struct {
+	Title  string
+	Source string
+	Link   string
+	// Tags lists the seniority and technologies of the job.
+	Tags []string
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, notifications []Notification, opts ...NotifyOption) error
+}
@@ -0,0 +1,46 @@
+package notifier
+
+import "context"
+
+type Option func(*Options)
+
+type Options struct {
+	Location string
+	Context  context.Context
+}
+
+func WithLocation(loc string) Option {
+	return func(o *Options) {
+		o.Location = loc
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		Context: context.Background(),
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
+
+type NotifyOption func(*NotifyOptions)
+
+type NotifyOptions struct {
+	Context context.Context
+}
+
+func NewNotifyOptions(opts ...NotifyOption) NotifyOptions {
+	options := NotifyOptions{
+		Context: context.Background(),
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
@@ -0,0 +1,49 @@
+package notifier
+
+import (
+	"fmt"
+	"sync"
+)
+
+type Factory func(opts ...Option) Notifier
+
+var (
+	factories = map[NotifierType]Factory{}
+	mtx       sync.RWMutex
+)
+
+// Register makes a notifier implementation available to New under the given type.
+// Implementations call it from an init function.
+func Register(t NotifierType, factory Factory) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if _, ok := factories[t]; ok {
+		panic(fmt.Sprintf("notifier %q already registered", t))
+	}
+
+	factories[t] = factory
+}
+
+// Registered reports whether an implementation is registered under the given type.
+func Registered(t NotifierType) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	_, ok := factories[t]
+
+	return ok
+}
+
+// New builds the notifier registered under the given type.
+func New(t NotifierType, opts ...Option) (Notifier, error) {
+	mtx.RLock()
+	factory, ok := factories[t]
+	mtx.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no notifier registered for type %q", t)
+	}
+
+	return factory(opts...), nil
+}
@@ -0,0 +1,131 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/w-h-a/scraper/internal/clients/notifier"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func init() {
+	notifier.Register(notifier.Slack, NewNotifier)
+}
+
+// keeps a single message well inside slack's text limits
+const maxListedJobs = 50
+
+// bounds a webhook post so a stalled slack cannot hold up the hunt
+const postTimeout = 10 * time.Second
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type slackNotifier struct {
+	options notifier.Options
+	client  *http.Client
+	tracer  trace.Tracer
+}
+
+// Notify posts all notifications as one message to the incoming webhook.
+func (n *slackNotifier) Notify(ctx context.Context, notifications []notifier.Notification, _ ...notifier.NotifyOption) error {
+	ctx, span := n.tracer.Start(ctx, "slack.Notify")
+	defer span.End()
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	span.SetAttributes(attribute.Int("notifications.count", len(notifications)))
+
+	body, err := json.Marshal(message{Text: format(notifications)})
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to encode slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.options.Location, bytes.NewReader(body))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to build slack request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := n.client.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to post slack message: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(rsp.Body, 512))
+		err := fmt.Errorf("slack webhook returned %s: %s", rsp.Status, strings.TrimSpace(string(detail)))
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func format(notifications []notifier.Notification) string {
+	var b strings.Builder
+
+	noun := "jobs"
+	if len(notifications) == 1 {
+		noun = "job"
+	}
+
+	fmt.Fprintf(&b, "*%d new %s found*\n", len(notifications), noun)
+
+	for i, n := range notifications {
+		if i == maxListedJobs {
+			fmt.Fprintf(&b, "…and %d more\n", len(notifications)-maxListedJobs)
+			break
+		}
+
+		fmt.Fprintf(&b, "• <%s|%s> (%s)%s\n", escapeLink(n.Link), escape(n.Title), escape(n.Source), formatTags(n.Tags))
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatTags renders tags as inline code after the job, e.g. " `go` `grpc`".
+func formatTags(tags []string) string {
+	var b strings.Builder
+	for _, tag := range tags {
+		fmt.Fprintf(&b, " `%s`", escape(strings.ReplaceAll(tag, "`", "'")))
+	}
+	return b.String()
+}
+
+// escape applies slack's control character escaping for mrkdwn text.
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "|", "¦").Replace(s)
+}
+
+func escapeLink(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "%3C", ">", "%3E", "|", "%7C").Replace(s)
+}
+
+func NewNotifier(opts ...notifier.Option) notifier.Notifier {
+	options := notifier.NewOptions(opts...)
+
+	n := &slackNotifier{
+		options: options,
+		client:  &http.Client{Timeout: postTimeout},
+		tracer:  otel.Tracer("slack-notifier"),
+	}
+
+	return n
+}
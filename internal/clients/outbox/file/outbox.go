@@ -0,0 +1,152 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/w-h-a/scraper/internal/clients/outbox"
+)
+
+func init() {
+	outbox.Register(outbox.File, NewOutbox)
+}
+
+const defaultPath = "outbox.json"
+
+// fileOutbox keeps the pending entries in one JSON document that is replaced
+// through a synced temp file on every change, so a crash leaves either the old
+// or the new document and never a torn one.
+type fileOutbox struct {
+	options outbox.Options
+	path    string
+	mtx     sync.Mutex
+}
+
+func (o *fileOutbox) Put(_ context.Context, entries []outbox.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	pending, err := o.load()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		i := slices.IndexFunc(pending, func(e outbox.Entry) bool { return e.Key == entry.Key })
+		if i >= 0 {
+			pending[i] = entry
+			continue
+		}
+		pending = append(pending, entry)
+	}
+
+	return o.save(pending)
+}
+
+func (o *fileOutbox) Pending(_ context.Context) ([]outbox.Entry, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	return o.load()
+}
+
+func (o *fileOutbox) Remove(_ context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	pending, err := o.load()
+	if err != nil {
+		return err
+	}
+
+	remaining := slices.DeleteFunc(pending, func(e outbox.Entry) bool {
+		return slices.Contains(keys, e.Key)
+	})
+
+	return o.save(remaining)
+}
+
+func (o *fileOutbox) load() ([]outbox.Entry, error) {
+	data, err := os.ReadFile(o.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox at %s: %w", o.path, err)
+	}
+
+	var entries []outbox.Entry
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse outbox at %s: %w", o.path, err)
+	}
+
+	return entries, nil
+}
+
+func (o *fileOutbox) save(entries []outbox.Entry) error {
+	if entries == nil {
+		entries = []outbox.Entry{}
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(o.path), filepath.Base(o.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write outbox at %s: %w", o.path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write outbox at %s: %w", o.path, err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write outbox at %s: %w", o.path, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write outbox at %s: %w", o.path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), o.path); err != nil {
+		return fmt.Errorf("failed to write outbox at %s: %w", o.path, err)
+	}
+
+	return nil
+}
+
+func NewOutbox(opts ...outbox.Option) outbox.Outbox {
+	options := outbox.NewOptions(opts...)
+
+	o := &fileOutbox{
+		options: options,
+		path:    defaultPath,
+	}
+
+	if len(options.Location) > 0 {
+		o.path = options.Location
+	}
+
+	return o
+}
@@ -0,0 +1,20 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/w-h-a/scraper/internal/clients/outbox"
+)
+
+type putErrKey struct{}
+
+func WithPutErr(err error) outbox.Option {
+	return func(o *outbox.Options) {
+		o.Context = context.WithValue(o.Context, putErrKey{}, err)
+	}
+}
+
+func getPutErrFromCtx(ctx context.Context) (error, bool) {
+	err, ok := ctx.Value(putErrKey{}).(error)
+	return err, ok
+}
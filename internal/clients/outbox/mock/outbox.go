@@ -0,0 +1,74 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/w-h-a/scraper/internal/clients/outbox"
+)
+
+func init() {
+	outbox.Register(outbox.Mock, func(opts ...outbox.Option) outbox.Outbox {
+		return NewOutbox(opts...)
+	})
+}
+
+type mockOutbox struct {
+	options outbox.Options
+	entries []outbox.Entry
+	putErr  error
+	mtx     sync.Mutex
+}
+
+func (o *mockOutbox) Put(_ context.Context, entries []outbox.Entry) error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	if o.putErr != nil {
+		return o.putErr
+	}
+
+	for _, entry := range entries {
+		i := slices.IndexFunc(o.entries, func(e outbox.Entry) bool { return e.Key == entry.Key })
+		if i >= 0 {
+			o.entries[i] = entry
+			continue
+		}
+		o.entries = append(o.entries, entry)
+	}
+
+	return nil
+}
+
+func (o *mockOutbox) Pending(_ context.Context) ([]outbox.Entry, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	return slices.Clone(o.entries), nil
+}
+
+func (o *mockOutbox) Remove(_ context.Context, keys []string) error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	o.entries = slices.DeleteFunc(o.entries, func(e outbox.Entry) bool {
+		return slices.Contains(keys, e.Key)
+	})
+
+	return nil
+}
+
+func NewOutbox(opts ...outbox.Option) *mockOutbox {
+	options := outbox.NewOptions(opts...)
+
+	o := &mockOutbox{
+		options: options,
+	}
+
+	if err, ok := getPutErrFromCtx(options.Context); ok {
+		o.putErr = err
+	}
+
+	return o
+}
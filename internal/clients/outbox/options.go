@@ -0,0 +1,28 @@
+package outbox
+
+import "context"
+
+type Option func(*Options)
+
+type Options struct {
+	Location string
+	Context  context.Context
+}
+
+func WithLocation(loc string) Option {
+	return func(o *Options) {
+		o.Location = loc
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		Context: context.Background(),
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
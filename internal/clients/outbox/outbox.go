@@ -0,0 +1,26 @@
+package outbox
+
+import "context"
+
+type OutboxType string
+
+const (
+	Mock OutboxType = "mock"
+	File OutboxType = "file"
+)
+
+// Entry is one item waiting to be written, keyed so it can be removed once
+// the write lands.
+type Entry struct {
+	Key     string `json:"key"`
+	Payload []byte `json:"payload"`
+}
+
+// Outbox keeps entries durable across failed writes and restarts.
+type Outbox interface {
+	// Put stores entries, replacing any pending entry with the same key.
+	Put(ctx context.Context, entries []Entry) error
+	// Pending returns every stored entry in the order it was first put.
+	Pending(ctx context.Context) ([]Entry, error)
+	Remove(ctx context.Context, keys []string) error
+}
@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"fmt"
+	"sync"
+)
+
+type Factory func(opts ...Option) Outbox
+
+var (
+	factories = map[OutboxType]Factory{}
+	mtx       sync.RWMutex
+)
+
+// Register makes an outbox implementation available to New under the given type.
+// Implementations call it from an init function.
+func Register(t OutboxType, factory Factory) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if _, ok := factories[t]; ok {
+		panic(fmt.Sprintf("outbox %q already registered", t))
+	}
+
+	factories[t] = factory
+}
+
+// Registered reports whether an implementation is registered under the given type.
+func Registered(t OutboxType) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	_, ok := factories[t]
+
+	return ok
+}
+
+// New builds the outbox registered under the given type.
+func New(t OutboxType, opts ...Option) (Outbox, error) {
+	mtx.RLock()
+	factory, ok := factories[t]
+	mtx.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no outbox registered for type %q", t)
+	}
+
+	return factory(opts...), nil
+}
@@ -5,3 +5,9 @@ import "context"
 type Reader interface {
 	ReadExisting(ctx context.Context, opts ...ReadExistingOption) (map[string]bool, error)
 }
+
+// LinkLooker is implemented by readers whose ReadExisting leaves stored links
+// out, so that the links of a cycle are checked against the store on demand.
+type LinkLooker interface {
+	LookupLinks(ctx context.Context, links []string) (map[string]bool, error)
+}
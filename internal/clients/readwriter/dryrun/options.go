@@ -0,0 +1,21 @@
+package dryrun
+
+import (
+	"context"
+
+	"github.com/w-h-a/scraper/internal/clients/readwriter"
+)
+
+type readWriterKey struct{}
+
+// WithReadWriter sets the store that existing links are read from.
+func WithReadWriter(rw readwriter.ReadWriter) readwriter.Option {
+	return func(o *readwriter.Options) {
+		o.Context = context.WithValue(o.Context, readWriterKey{}, rw)
+	}
+}
+
+func getReadWriterFromCtx(ctx context.Context) (readwriter.ReadWriter, bool) {
+	rw, ok := ctx.Value(readWriterKey{}).(readwriter.ReadWriter)
+	return rw, ok
+}
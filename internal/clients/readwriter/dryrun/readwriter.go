@@ -0,0 +1,71 @@
+// Package dryrun wraps a readwriter so that reads reach the real store while
+// writes are only recorded. It is not registered as a READ_WRITER type: it is
+// a mode of the hunt command, not a backend.
+package dryrun
+
+import (
+	"context"
+	"sync"
+
+	"github.com/w-h-a/scraper/internal/clients/reader"
+	"github.com/w-h-a/scraper/internal/clients/readwriter"
+	"github.com/w-h-a/scraper/internal/clients/writer"
+)
+
+type dryRunReadWriter struct {
+	options readwriter.Options
+	inner   readwriter.ReadWriter
+	rows    [][]any
+	mtx     sync.Mutex
+}
+
+func (rw *dryRunReadWriter) ReadExisting(ctx context.Context, opts ...reader.ReadExistingOption) (map[string]bool, error) {
+	if rw.inner == nil {
+		return map[string]bool{}, nil
+	}
+	return rw.inner.ReadExisting(ctx, opts...)
+}
+
+// LookupLinks forwards to the wrapped store when it looks links up on demand.
+func (rw *dryRunReadWriter) LookupLinks(ctx context.Context, links []string) (map[string]bool, error) {
+	looker, ok := rw.inner.(reader.LinkLooker)
+	if !ok {
+		return nil, nil
+	}
+	return looker.LookupLinks(ctx, links)
+}
+
+func (rw *dryRunReadWriter) WriteBatch(_ context.Context, rows [][]any, _ ...writer.WriteBatchOption) (writer.WriteResult, error) {
+	rw.mtx.Lock()
+	defer rw.mtx.Unlock()
+
+	rw.rows = append(rw.rows, rows...)
+
+	return writer.WriteResult{}, nil
+}
+
+func (rw *dryRunReadWriter) ClearBatch(_ context.Context, _ ...writer.ClearBatchOption) error {
+	return nil
+}
+
+// RowsWritten returns every row that would have been written so far.
+func (rw *dryRunReadWriter) RowsWritten() [][]any {
+	rw.mtx.Lock()
+	defer rw.mtx.Unlock()
+
+	return append([][]any(nil), rw.rows...)
+}
+
+func NewReadWriter(opts ...readwriter.Option) *dryRunReadWriter {
+	options := readwriter.NewOptions(opts...)
+
+	rw := &dryRunReadWriter{
+		options: options,
+	}
+
+	if inner, ok := getReadWriterFromCtx(options.Context); ok {
+		rw.inner = inner
+	}
+
+	return rw
+}
@@ -10,6 +10,8 @@ type existingLinksKey struct{}
 type readErrKey struct{}
 type rowsWrittenKey struct{}
 type writeErrKey struct{}
+type skippedLinksKey struct{}
+type storedLinksKey struct{}
 
 func WithExistingLinksKey(existing map[string]bool) readwriter.Option {
 	return func(o *readwriter.Options) {
@@ -54,3 +56,29 @@ func getWriteErrFromCtx(ctx context.Context) (error, bool) {
 	err, ok := ctx.Value(writeErrKey{}).(error)
 	return err, ok
 }
+
+// WithSkippedLinks makes WriteBatch skip rows with these links, as a store
+// with a unique constraint on link would.
+func WithSkippedLinks(links ...string) readwriter.Option {
+	return func(o *readwriter.Options) {
+		o.Context = context.WithValue(o.Context, skippedLinksKey{}, links)
+	}
+}
+
+func getSkippedLinksFromCtx(ctx context.Context) ([]string, bool) {
+	links, ok := ctx.Value(skippedLinksKey{}).([]string)
+	return links, ok
+}
+
+// WithStoredLinks makes LookupLinks report these links as stored, as a store
+// that leaves primary links out of ReadExisting would.
+func WithStoredLinks(links ...string) readwriter.Option {
+	return func(o *readwriter.Options) {
+		o.Context = context.WithValue(o.Context, storedLinksKey{}, links)
+	}
+}
+
+func getStoredLinksFromCtx(ctx context.Context) ([]string, bool) {
+	links, ok := ctx.Value(storedLinksKey{}).([]string)
+	return links, ok
+}
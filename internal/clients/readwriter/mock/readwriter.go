@@ -8,21 +8,50 @@ import (
 	"github.com/w-h-a/scraper/internal/clients/writer"
 )
 
+func init() {
+	readwriter.Register(readwriter.Mock, func(opts ...readwriter.Option) readwriter.ReadWriter {
+		return NewReadWriter(opts...)
+	})
+}
+
 type mockReadWriter struct {
 	options       readwriter.Options
 	existingLinks map[string]bool
 	readErr       error
 	RowsWritten   [][]any
 	writeErr      error
+	skippedLinks  map[string]bool
+	storedLinks   map[string]bool
 }
 
 func (rw *mockReadWriter) ReadExisting(_ context.Context, _ ...reader.ReadExistingOption) (map[string]bool, error) {
 	return rw.existingLinks, rw.readErr
 }
 
-func (rw *mockReadWriter) WriteBatch(_ context.Context, rows [][]any, opts ...writer.WriteBatchOption) error {
+func (rw *mockReadWriter) LookupLinks(_ context.Context, links []string) (map[string]bool, error) {
+	stored := map[string]bool{}
+	for _, link := range links {
+		if rw.storedLinks[link] {
+			stored[link] = true
+		}
+	}
+	return stored, rw.readErr
+}
+
+func (rw *mockReadWriter) WriteBatch(_ context.Context, rows [][]any, opts ...writer.WriteBatchOption) (writer.WriteResult, error) {
 	rw.RowsWritten = rows
-	return rw.writeErr
+
+	var result writer.WriteResult
+	for _, row := range rows {
+		if len(row) < 4 {
+			continue
+		}
+		if link, ok := row[3].(string); ok && rw.skippedLinks[link] {
+			result.Skipped = append(result.Skipped, link)
+		}
+	}
+
+	return result, rw.writeErr
 }
 
 func (rw *mockReadWriter) ClearBatch(_ context.Context, opts ...writer.ClearBatchOption) error {
@@ -52,5 +81,19 @@ func NewReadWriter(opts ...readwriter.Option) *mockReadWriter {
 		rw.writeErr = err
 	}
 
+	if links, ok := getSkippedLinksFromCtx(options.Context); ok {
+		rw.skippedLinks = map[string]bool{}
+		for _, link := range links {
+			rw.skippedLinks[link] = true
+		}
+	}
+
+	if links, ok := getStoredLinksFromCtx(options.Context); ok {
+		rw.storedLinks = map[string]bool{}
+		for _, link := range links {
+			rw.storedLinks[link] = true
+		}
+	}
+
 	return rw
 }
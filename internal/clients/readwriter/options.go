@@ -6,7 +6,9 @@ type Option func(*Options)
 
 type Options struct {
 	Location string
-	Context  context.Context
+	// SkipMigrations opens a database store without changing its schema.
+	SkipMigrations bool
+	Context        context.Context
 }
 
 func WithLocation(loc string) Option {
@@ -15,6 +17,14 @@ func WithLocation(loc string) Option {
 	}
 }
 
+// WithoutMigrations opens a database store as it is, without applying schema
+// migrations, as a dry run needs. A store with no schema yet reads as empty.
+func WithoutMigrations() Option {
+	return func(o *Options) {
+		o.SkipMigrations = true
+	}
+}
+
 func NewOptions(opts ...Option) Options {
 	options := Options{
 		Context: context.Background(),
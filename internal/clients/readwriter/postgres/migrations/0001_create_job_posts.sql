@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS job_posts (
+    id BIGSERIAL PRIMARY KEY,
+    date_posted TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL DEFAULT '',
+    job_title TEXT NOT NULL DEFAULT '',
+    link TEXT NOT NULL,
+    raw_description TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS job_posts_link_idx ON job_posts (link);
@@ -0,0 +1,4 @@
+ALTER TABLE job_posts
+    ADD COLUMN IF NOT EXISTS team TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS location TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS employment_type TEXT NOT NULL DEFAULT '';
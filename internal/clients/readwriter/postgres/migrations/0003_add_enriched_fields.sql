@@ -0,0 +1,8 @@
+ALTER TABLE job_posts
+    ADD COLUMN IF NOT EXISTS company TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS salary_min TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS salary_max TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS salary_currency TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS salary_period TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS workplace TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS valid_through TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE job_posts
+    ADD COLUMN IF NOT EXISTS also_listed_at TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE job_posts
+    ADD COLUMN IF NOT EXISTS regions TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE job_posts
+    ADD COLUMN IF NOT EXISTS tags TEXT NOT NULL DEFAULT '';
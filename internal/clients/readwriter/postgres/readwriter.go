@@ -0,0 +1,290 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/w-h-a/scraper/internal/clients/reader"
+	"github.com/w-h-a/scraper/internal/clients/readwriter"
+	"github.com/w-h-a/scraper/internal/clients/writer"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func init() {
+	readwriter.Register(readwriter.Postgres, NewReadWriter)
+}
+
+// columns follow the order of the generic rows handed to WriteBatch.
+var columns = []string{
+	"date_posted",
+	"source",
+	"job_title",
+	"link",
+	"raw_description",
+	"status",
+	"team",
+	"location",
+	"employment_type",
+	"company",
+	"salary_min",
+	"salary_max",
+	"salary_currency",
+	"salary_period",
+	"workplace",
+	"valid_through",
+	"also_listed_at",
+	"regions",
+	"timezone",
+	"tags",
+}
+
+var linkColumn = slices.Index(columns, "link")
+
+type postgresReadWriter struct {
+	options readwriter.Options
+	db      *sql.DB
+	tracer  trace.Tracer
+	// empty is set when migrations were skipped on a database without the
+	// job_posts table, so that reads find nothing instead of failing
+	empty bool
+}
+
+// ReadExisting returns only the links of duplicates merged into stored rows.
+// Primary links are not loaded into memory: LookupLinks checks the links of a
+// cycle against the index on link instead. The query option is a sheets
+// range and has no meaning for a database, so it is ignored.
+func (rw *postgresReadWriter) ReadExisting(ctx context.Context, _ ...reader.ReadExistingOption) (map[string]bool, error) {
+	ctx, span := rw.tracer.Start(ctx, "postgres.ReadExisting")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("db.operation", "read_links"))
+
+	if rw.empty {
+		return map[string]bool{}, nil
+	}
+
+	rows, err := rw.db.QueryContext(ctx, "SELECT also_listed_at FROM job_posts WHERE also_listed_at <> ''")
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to query links: %w", err)
+	}
+	defer rows.Close()
+
+	existingLinks := map[string]bool{}
+
+	for rows.Next() {
+		var alsoListedAt string
+		if err := rows.Scan(&alsoListedAt); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to scan link: %w", err)
+		}
+		for _, other := range strings.Fields(alsoListedAt) {
+			existingLinks[other] = true
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to iterate links: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("deduplication.count", len(existingLinks)))
+
+	return existingLinks, nil
+}
+
+// LookupLinks returns which of links are stored as the link of a row.
+func (rw *postgresReadWriter) LookupLinks(ctx context.Context, links []string) (map[string]bool, error) {
+	ctx, span := rw.tracer.Start(ctx, "postgres.LookupLinks")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "lookup_links"),
+		attribute.Int("links.count", len(links)),
+	)
+
+	if rw.empty {
+		return map[string]bool{}, nil
+	}
+
+	rows, err := rw.db.QueryContext(ctx, "SELECT link FROM job_posts WHERE link = ANY($1)", links)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to look up links: %w", err)
+	}
+	defer rows.Close()
+
+	stored := map[string]bool{}
+
+	for rows.Next() {
+		var link string
+		if err := rows.Scan(&link); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to scan link: %w", err)
+		}
+		stored[link] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to look up links: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("links.stored", len(stored)))
+
+	return stored, nil
+}
+
+// WriteBatch inserts all rows in one transaction. Rows whose link is already
+// stored are skipped by the database and reported in the result, so callers
+// need not dedup first.
+func (rw *postgresReadWriter) WriteBatch(ctx context.Context, rows [][]any, _ ...writer.WriteBatchOption) (writer.WriteResult, error) {
+	ctx, span := rw.tracer.Start(ctx, "postgres.WriteBatch")
+	defer span.End()
+
+	if len(rows) == 0 {
+		return writer.WriteResult{}, nil
+	}
+
+	span.SetAttributes(attribute.Int("rows.count", len(rows)))
+	span.SetAttributes(attribute.String("db.operation", "insert_rows"))
+
+	tx, err := rw.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		return writer.WriteResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	placeholders := make([]string, len(columns))
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(
+		"INSERT INTO job_posts (%s) VALUES (%s) ON CONFLICT (link) DO NOTHING",
+		strings.Join(columns, ", "),
+		strings.Join(placeholders, ", "),
+	))
+	if err != nil {
+		span.RecordError(err)
+		return writer.WriteResult{}, fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	written := 0
+
+	var skipped []string
+
+	for i, row := range rows {
+		values, err := rowValues(row)
+		if err != nil {
+			span.RecordError(err)
+			return writer.WriteResult{}, fmt.Errorf("row %d: %w", i, err)
+		}
+
+		res, err := stmt.ExecContext(ctx, values...)
+		if err != nil {
+			span.RecordError(err)
+			return writer.WriteResult{}, fmt.Errorf("failed to insert row %d: %w", i, err)
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			span.RecordError(err)
+			return writer.WriteResult{}, fmt.Errorf("failed to count inserted row %d: %w", i, err)
+		}
+
+		if n == 0 {
+			skipped = append(skipped, values[linkColumn].(string))
+		}
+
+		written += int(n)
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		return writer.WriteResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("rows.conflicted", len(rows)-written))
+	span.AddEvent("DataSuccessfullyAppended", trace.WithAttributes(attribute.Int("records.written", written)))
+
+	return writer.WriteResult{Skipped: skipped}, nil
+}
+
+func (rw *postgresReadWriter) ClearBatch(ctx context.Context, _ ...writer.ClearBatchOption) error {
+	_, err := rw.db.ExecContext(ctx, "TRUNCATE job_posts")
+	return err
+}
+
+// Close releases the database handle.
+func (rw *postgresReadWriter) Close() error {
+	return rw.db.Close()
+}
+
+func (rw *postgresReadWriter) configure(dsn string) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		detail := fmt.Sprintf("failed to open postgres connection: %v", err)
+		panic(detail)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rw.db = db
+
+	if rw.options.SkipMigrations {
+		var exists bool
+		if err := db.QueryRowContext(ctx, "SELECT to_regclass('job_posts') IS NOT NULL").Scan(&exists); err != nil {
+			detail := fmt.Sprintf("failed to inspect postgres schema: %v", err)
+			panic(detail)
+		}
+		rw.empty = !exists
+		return
+	}
+
+	if err := migrate(ctx, db); err != nil {
+		detail := fmt.Sprintf("failed to migrate postgres schema: %v", err)
+		panic(detail)
+	}
+}
+
+func rowValues(row []any) ([]any, error) {
+	if len(row) > len(columns) {
+		return nil, fmt.Errorf("has %d cells but only %d columns are stored", len(row), len(columns))
+	}
+
+	values := make([]any, len(columns))
+
+	for i := range values {
+		if i < len(row) && row[i] != nil {
+			values[i] = fmt.Sprintf("%v", row[i])
+		} else {
+			values[i] = ""
+		}
+	}
+
+	return values, nil
+}
+
+func NewReadWriter(opts ...readwriter.Option) readwriter.ReadWriter {
+	options := readwriter.NewOptions(opts...)
+
+	rw := &postgresReadWriter{
+		options: options,
+		tracer:  otel.Tracer("postgres-readwriter"),
+	}
+
+	rw.configure(options.Location)
+
+	return rw
+}
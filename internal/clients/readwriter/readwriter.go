@@ -8,15 +8,10 @@ import (
 type ReadWriterType string
 
 const (
-	Mock   ReadWriterType = "mock"
-	Sheets ReadWriterType = "sheets"
-)
-
-var (
-	ReadWriterTypes = map[string]ReadWriterType{
-		"mock":   Mock,
-		"sheets": Sheets,
-	}
+	Mock     ReadWriterType = "mock"
+	Sheets   ReadWriterType = "sheets"
+	SQLite   ReadWriterType = "sqlite"
+	Postgres ReadWriterType = "postgres"
 )
 
 type ReadWriter interface {
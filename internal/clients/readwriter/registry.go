@@ -0,0 +1,49 @@
+package readwriter
+
+import (
+	"fmt"
+	"sync"
+)
+
+type Factory func(opts ...Option) ReadWriter
+
+var (
+	factories = map[ReadWriterType]Factory{}
+	mtx       sync.RWMutex
+)
+
+// Register makes a readwriter implementation available to New under the given type.
+// Implementations call it from an init function.
+func Register(t ReadWriterType, factory Factory) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if _, ok := factories[t]; ok {
+		panic(fmt.Sprintf("readwriter %q already registered", t))
+	}
+
+	factories[t] = factory
+}
+
+// Registered reports whether an implementation is registered under the given type.
+func Registered(t ReadWriterType) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	_, ok := factories[t]
+
+	return ok
+}
+
+// New builds the readwriter registered under the given type.
+func New(t ReadWriterType, opts ...Option) (ReadWriter, error) {
+	mtx.RLock()
+	factory, ok := factories[t]
+	mtx.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no readwriter registered for type %q", t)
+	}
+
+	return factory(opts...), nil
+}
@@ -0,0 +1,41 @@
+package sheets
+
+import "strings"
+
+// formulaTriggers make a USER_ENTERED cell a formula when it starts with
+// one of them. Tab and carriage return are included because spreadsheet
+// apps that import our data as CSV treat them the same way, and a leading
+// apostrophe would otherwise be swallowed as the text marker.
+const formulaTriggers = "=+-@\t\r'"
+
+// escapeRows returns rows with every cell that could run as a formula
+// turned into literal text. Appends stay USER_ENTERED so that links still
+// become clickable hyperlinks and numbers stay numbers.
+func escapeRows(rows [][]any) [][]any {
+	escaped := make([][]any, len(rows))
+
+	for i, row := range rows {
+		escaped[i] = make([]any, len(row))
+		for j, cell := range row {
+			escaped[i][j] = escapeCell(cell)
+		}
+	}
+
+	return escaped
+}
+
+// escapeCell prefixes a dangerous string with an apostrophe, which Sheets
+// stores as a text marker and does not display or return on read.
+func escapeCell(cell any) any {
+	s, ok := cell.(string)
+	if !ok {
+		return cell
+	}
+
+	trimmed := strings.TrimLeft(s, " \n")
+	if len(trimmed) == 0 || !strings.ContainsRune(formulaTriggers, rune(trimmed[0])) {
+		return s
+	}
+
+	return "'" + s
+}
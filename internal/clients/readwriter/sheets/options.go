@@ -4,9 +4,13 @@ import (
 	"context"
 
 	"github.com/w-h-a/scraper/internal/clients/readwriter"
+	"github.com/w-h-a/scraper/internal/clients/retry"
 )
 
 type serviceAccountKeyPathKey struct{}
+type endpointKey struct{}
+type chunkSizeKey struct{}
+type retryKey struct{}
 
 func WithServiceAccountKeyPath(path string) readwriter.Option {
 	return func(o *readwriter.Options) {
@@ -18,3 +22,40 @@ func getServiceAccountKeyPathFromCtx(context context.Context) (string, bool) {
 	path, ok := context.Value(serviceAccountKeyPathKey{}).(string)
 	return path, ok
 }
+
+// WithEndpoint points the client at a different Sheets API host. Without a
+// service account key the requests are sent unauthenticated.
+func WithEndpoint(url string) readwriter.Option {
+	return func(o *readwriter.Options) {
+		o.Context = context.WithValue(o.Context, endpointKey{}, url)
+	}
+}
+
+func getEndpointFromCtx(ctx context.Context) (string, bool) {
+	url, ok := ctx.Value(endpointKey{}).(string)
+	return url, ok
+}
+
+// WithChunkSize caps how many rows go into one append request. Defaults to 500.
+func WithChunkSize(n int) readwriter.Option {
+	return func(o *readwriter.Options) {
+		o.Context = context.WithValue(o.Context, chunkSizeKey{}, n)
+	}
+}
+
+func getChunkSizeFromCtx(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(chunkSizeKey{}).(int)
+	return n, ok
+}
+
+// WithRetry tunes how appends are retried on quota and server errors.
+func WithRetry(opts ...retry.Option) readwriter.Option {
+	return func(o *readwriter.Options) {
+		o.Context = context.WithValue(o.Context, retryKey{}, opts)
+	}
+}
+
+func getRetryFromCtx(ctx context.Context) ([]retry.Option, bool) {
+	opts, ok := ctx.Value(retryKey{}).([]retry.Option)
+	return opts, ok
+}
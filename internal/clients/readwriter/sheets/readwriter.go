@@ -2,25 +2,43 @@ package sheets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/w-h-a/scraper/internal/clients/reader"
 	"github.com/w-h-a/scraper/internal/clients/readwriter"
+	"github.com/w-h-a/scraper/internal/clients/retry"
 	"github.com/w-h-a/scraper/internal/clients/writer"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 )
 
+func init() {
+	readwriter.Register(readwriter.Sheets, NewReadWriter)
+}
+
+const defaultChunkSize = 500
+
+// linkRange is the column that holds each row's link.
+const linkRange = "D:D"
+
+// alsoListedRange is the column that lists the links of merged duplicates.
+const alsoListedRange = "Q:Q"
+
 type sheetsReadWriter struct {
-	options readwriter.Options
-	client  *sheets.Service
-	tracer  trace.Tracer
+	options   readwriter.Options
+	client    *sheets.Service
+	tracer    trace.Tracer
+	chunkSize int
+	retry     retry.Options
 }
 
 func (s *sheetsReadWriter) ReadExisting(ctx context.Context, opts ...reader.ReadExistingOption) (map[string]bool, error) {
@@ -32,7 +50,7 @@ func (s *sheetsReadWriter) ReadExisting(ctx context.Context, opts ...reader.Read
 	fullRange := "Sheet1" + "!" + options.Query
 	span.SetAttributes(attribute.String("db.operation", "read_links"))
 
-	rsp, err := s.client.Spreadsheets.Values.Get(s.options.Location, fullRange).Context(ctx).Do()
+	rsp, err := s.client.Spreadsheets.Values.BatchGet(s.options.Location).Ranges(fullRange, "Sheet1"+"!"+alsoListedRange).Context(ctx).Do()
 	if err != nil {
 		if strings.Contains(err.Error(), "Unable to parse range") {
 			span.AddEvent("SheetEmpty", trace.WithAttributes(attribute.String("warning", "sheet range was empty")))
@@ -46,12 +64,30 @@ func (s *sheetsReadWriter) ReadExisting(ctx context.Context, opts ...reader.Read
 
 	const linkColIndex = 3
 
-	for i, row := range rsp.Values {
+	// the first range holds the link column, the second the links of
+	// duplicates merged into each row
+	var links, alsoListed [][]any
+	if len(rsp.ValueRanges) > 0 {
+		links = rsp.ValueRanges[0].Values
+	}
+	if len(rsp.ValueRanges) > 1 {
+		alsoListed = rsp.ValueRanges[1].Values
+	}
+
+	for i, row := range links {
 		if i == 0 || len(row) <= linkColIndex {
 			continue
 		}
-		link := fmt.Sprintf("%v", row[linkColIndex])
-		existingLinks[link] = true
+		existingLinks[fmt.Sprintf("%v", row[linkColIndex])] = true
+	}
+
+	for i, row := range alsoListed {
+		if i == 0 || len(row) == 0 {
+			continue
+		}
+		for _, link := range strings.Fields(fmt.Sprintf("%v", row[0])) {
+			existingLinks[link] = true
+		}
 	}
 
 	span.SetAttributes(attribute.Int("deduplication.count", len(existingLinks)))
@@ -59,29 +95,141 @@ func (s *sheetsReadWriter) ReadExisting(ctx context.Context, opts ...reader.Read
 	return existingLinks, nil
 }
 
-func (s *sheetsReadWriter) WriteBatch(ctx context.Context, rows [][]any, _ ...writer.WriteBatchOption) error {
-	_, span := s.tracer.Start(ctx, "sheets.WriteBatch")
+// WriteBatch appends rows in chunks, in order. A chunk that fails with a
+// quota or server error is retried with backoff; when a chunk finally fails
+// after earlier ones landed, the error is a *writer.PartialWriteError.
+// Cells from feeds are untrusted, so any that would start a formula are
+// written as text.
+func (s *sheetsReadWriter) WriteBatch(ctx context.Context, rows [][]any, _ ...writer.WriteBatchOption) (writer.WriteResult, error) {
+	ctx, span := s.tracer.Start(ctx, "sheets.WriteBatch")
 	defer span.End()
 
 	if len(rows) == 0 {
-		return nil
+		return writer.WriteResult{}, nil
 	}
 
 	span.SetAttributes(attribute.Int("rows.count", len(rows)))
 	span.SetAttributes(attribute.String("db.operation", "append_data"))
+	span.SetAttributes(attribute.Int("rows.chunk_size", s.chunkSize))
 
-	var valueRange sheets.ValueRange
+	rows = escapeRows(rows)
 
-	valueRange.Values = rows
+	written := 0
 
-	if _, err := s.client.Spreadsheets.Values.Append(s.options.Location, "Sheet1"+"!"+"A:H", &valueRange).Context(ctx).ValueInputOption("USER_ENTERED").InsertDataOption("INSERT_ROWS").Do(); err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to append data to sheet: %w", err)
+	for start := 0; start < len(rows); start += s.chunkSize {
+		chunk := rows[start:min(start+s.chunkSize, len(rows))]
+
+		if err := s.appendChunk(ctx, chunk); err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.Int("records.written", written))
+
+			err = fmt.Errorf("failed to append data to sheet: %w", err)
+
+			if written > 0 {
+				return writer.WriteResult{}, &writer.PartialWriteError{Written: written, Total: len(rows), Err: err}
+			}
+
+			return writer.WriteResult{}, err
+		}
+
+		written += len(chunk)
+
+		span.AddEvent("ChunkAppended", trace.WithAttributes(attribute.Int("records.written", written)))
 	}
 
 	span.AddEvent("DataSuccessfullyAppended", trace.WithAttributes(attribute.Int("records.written", len(rows))))
 
-	return nil
+	return writer.WriteResult{}, nil
+}
+
+// appendChunk retries on 429 and 5xx. Appends are not idempotent and a server
+// error can arrive after the rows were stored, so before every retry the link
+// column is read back and a chunk whose links are all there counts as
+// appended. A Retry-After longer than the max delay gives up on the chunk.
+func (s *sheetsReadWriter) appendChunk(ctx context.Context, chunk [][]any) error {
+	valueRange := sheets.ValueRange{Values: chunk}
+
+	for attempt := 1; ; attempt++ {
+		_, err := s.client.Spreadsheets.Values.Append(s.options.Location, "Sheet1"+"!"+appendRange(chunk), &valueRange).Context(ctx).ValueInputOption("USER_ENTERED").InsertDataOption("INSERT_ROWS").Do()
+		if err == nil {
+			return nil
+		}
+
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) || !retry.RetryableStatus(apiErr.Code) || attempt >= s.retry.MaxAttempts {
+			return err
+		}
+
+		wait := retry.Backoff(s.retry, attempt)
+
+		if after, ok := retry.RetryAfter(apiErr.Header.Get("Retry-After"), time.Now()); ok {
+			if after > s.retry.MaxDelay {
+				return err
+			}
+			wait = after
+		}
+
+		if sleepErr := retry.Sleep(ctx, wait); sleepErr != nil {
+			return err
+		}
+
+		landed, checkErr := s.landed(ctx, chunk)
+		if checkErr != nil {
+			trace.SpanFromContext(ctx).RecordError(checkErr)
+		}
+
+		if landed {
+			trace.SpanFromContext(ctx).AddEvent("AppendAlreadyStored", trace.WithAttributes(
+				attribute.Int("http.status_code", apiErr.Code),
+				attribute.Int("attempt", attempt),
+			))
+			return nil
+		}
+
+		trace.SpanFromContext(ctx).AddEvent("AppendRetried", trace.WithAttributes(
+			attribute.Int("http.status_code", apiErr.Code),
+			attribute.Int("attempt", attempt),
+		))
+	}
+}
+
+// landed reports whether every link in chunk is already in the sheet.
+func (s *sheetsReadWriter) landed(ctx context.Context, chunk [][]any) (bool, error) {
+	const linkColIndex = 3
+
+	var links []string
+	for _, row := range chunk {
+		if len(row) > linkColIndex {
+			// reads do not return the apostrophe that escaped a cell
+			links = append(links, strings.TrimPrefix(fmt.Sprintf("%v", row[linkColIndex]), "'"))
+		}
+	}
+
+	if len(links) == 0 {
+		return false, nil
+	}
+
+	rsp, err := s.client.Spreadsheets.Values.BatchGet(s.options.Location).Ranges("Sheet1" + "!" + linkRange).Context(ctx).Do()
+	if err != nil {
+		return false, fmt.Errorf("failed to read back links: %w", err)
+	}
+
+	stored := map[string]bool{}
+	for _, vr := range rsp.ValueRanges {
+		for _, row := range vr.Values {
+			if len(row) > 0 {
+				stored[fmt.Sprintf("%v", row[0])] = true
+			}
+		}
+	}
+
+	for _, link := range links {
+		if !stored[link] {
+			return false, nil
+		}
+	}
+
+	return true, nil
 }
 
 func (s *sheetsReadWriter) ClearBatch(ctx context.Context, opts ...writer.ClearBatchOption) error {
@@ -120,6 +268,26 @@ func (s *sheetsReadWriter) ClearBatch(ctx context.Context, opts ...writer.ClearB
 	return err
 }
 
+// appendRange spans column A through the widest row being written.
+func appendRange(rows [][]any) string {
+	width := 1
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+
+	return "A:" + columnName(width)
+}
+
+func columnName(n int) string {
+	name := ""
+	for n > 0 {
+		n--
+		name = string(rune('A'+n%26)) + name
+		n /= 26
+	}
+	return name
+}
+
 func (rw *sheetsReadWriter) configure(path string) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -137,7 +305,13 @@ func (rw *sheetsReadWriter) configure(path string) {
 
 	client := config.Client(ctx)
 
-	sheetsClient, err := sheets.NewService(ctx, option.WithHTTPClient(client))
+	opts := []option.ClientOption{option.WithHTTPClient(client)}
+
+	if endpoint, ok := getEndpointFromCtx(rw.options.Context); ok {
+		opts = append(opts, option.WithEndpoint(endpoint))
+	}
+
+	sheetsClient, err := sheets.NewService(ctx, opts...)
 	if err != nil {
 		detail := fmt.Sprintf("failed to retrieve sheets client using key at %s: %v", path, err)
 		panic(detail)
@@ -146,16 +320,52 @@ func (rw *sheetsReadWriter) configure(path string) {
 	rw.client = sheetsClient
 }
 
+func (rw *sheetsReadWriter) configureEndpoint(endpoint string) {
+	sheetsClient, err := sheets.NewService(
+		context.Background(),
+		option.WithEndpoint(endpoint),
+		option.WithoutAuthentication(),
+	)
+	if err != nil {
+		detail := fmt.Sprintf("failed to retrieve sheets client for %s: %v", endpoint, err)
+		panic(detail)
+	}
+
+	rw.client = sheetsClient
+}
+
 func NewReadWriter(opts ...readwriter.Option) readwriter.ReadWriter {
 	options := readwriter.NewOptions(opts...)
 
+	// quota is refilled per minute, so back off harder than the scrapers do
+	retryOpts := []retry.Option{
+		retry.WithMaxAttempts(5),
+		retry.WithBaseDelay(time.Second),
+		retry.WithMaxDelay(time.Minute),
+	}
+
+	if opts, ok := getRetryFromCtx(options.Context); ok {
+		retryOpts = append(retryOpts, opts...)
+	}
+
 	rw := &sheetsReadWriter{
-		options: options,
-		tracer:  otel.Tracer("sheets-readwriter"),
+		options:   options,
+		tracer:    otel.Tracer("sheets-readwriter"),
+		chunkSize: defaultChunkSize,
+		retry:     retry.NewOptions(retryOpts...),
 	}
 
-	if path, ok := getServiceAccountKeyPathFromCtx(options.Context); ok {
+	if n, ok := getChunkSizeFromCtx(options.Context); ok && n > 0 {
+		rw.chunkSize = n
+	}
+
+	endpoint, hasEndpoint := getEndpointFromCtx(options.Context)
+	path, hasPath := getServiceAccountKeyPathFromCtx(options.Context)
+
+	if hasPath && (len(path) > 0 || !hasEndpoint) {
 		rw.configure(path)
+	} else if hasEndpoint {
+		rw.configureEndpoint(endpoint)
 	}
 
 	return rw
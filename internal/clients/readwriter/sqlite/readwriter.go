@@ -0,0 +1,343 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/w-h-a/scraper/internal/clients/reader"
+	"github.com/w-h-a/scraper/internal/clients/readwriter"
+	"github.com/w-h-a/scraper/internal/clients/writer"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	readwriter.Register(readwriter.SQLite, NewReadWriter)
+}
+
+const defaultLocation = "scraper.db"
+
+// columns follow the order of the generic rows handed to WriteBatch.
+var columns = []string{
+	"date_posted",
+	"source",
+	"job_title",
+	"link",
+	"raw_description",
+	"status",
+	"team",
+	"location",
+	"employment_type",
+	"company",
+	"salary_min",
+	"salary_max",
+	"salary_currency",
+	"salary_period",
+	"workplace",
+	"valid_through",
+	"also_listed_at",
+	"regions",
+	"timezone",
+	"tags",
+}
+
+var linkColumn = slices.Index(columns, "link")
+
+// migrations are applied in order and tracked with PRAGMA user_version.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS job_posts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		date_posted TEXT NOT NULL DEFAULT '',
+		source TEXT NOT NULL DEFAULT '',
+		job_title TEXT NOT NULL DEFAULT '',
+		link TEXT NOT NULL,
+		raw_description TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS job_posts_link_idx ON job_posts (link);`,
+	`ALTER TABLE job_posts ADD COLUMN team TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_posts ADD COLUMN location TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_posts ADD COLUMN employment_type TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE job_posts ADD COLUMN company TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_posts ADD COLUMN salary_min TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_posts ADD COLUMN salary_max TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_posts ADD COLUMN salary_currency TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_posts ADD COLUMN salary_period TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_posts ADD COLUMN workplace TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_posts ADD COLUMN valid_through TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE job_posts ADD COLUMN also_listed_at TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE job_posts ADD COLUMN regions TEXT NOT NULL DEFAULT '';
+	ALTER TABLE job_posts ADD COLUMN timezone TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE job_posts ADD COLUMN tags TEXT NOT NULL DEFAULT '';`,
+}
+
+type sqliteReadWriter struct {
+	options  readwriter.Options
+	db       *sql.DB
+	tracer   trace.Tracer
+	migrated bool
+	// empty is set when migrations were skipped on a database with no
+	// schema yet, so that reads find nothing instead of failing
+	empty bool
+	mtx   sync.Mutex
+}
+
+// ReadExisting returns every stored link, including the links of duplicates
+// merged into a row. The query option is a sheets range and has no meaning
+// for a database, so it is ignored.
+func (rw *sqliteReadWriter) ReadExisting(ctx context.Context, _ ...reader.ReadExistingOption) (map[string]bool, error) {
+	ctx, span := rw.tracer.Start(ctx, "sqlite.ReadExisting")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("db.operation", "read_links"))
+
+	if err := rw.migrate(ctx); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if rw.empty {
+		return map[string]bool{}, nil
+	}
+
+	rows, err := rw.db.QueryContext(ctx, "SELECT link, also_listed_at FROM job_posts")
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to query links: %w", err)
+	}
+	defer rows.Close()
+
+	existingLinks := map[string]bool{}
+
+	for rows.Next() {
+		var link, alsoListedAt string
+		if err := rows.Scan(&link, &alsoListedAt); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to scan link: %w", err)
+		}
+		existingLinks[link] = true
+		for _, other := range strings.Fields(alsoListedAt) {
+			existingLinks[other] = true
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to iterate links: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("deduplication.count", len(existingLinks)))
+
+	return existingLinks, nil
+}
+
+// WriteBatch inserts all rows in one transaction. Rows whose link is already
+// stored are skipped and reported in the result.
+func (rw *sqliteReadWriter) WriteBatch(ctx context.Context, rows [][]any, _ ...writer.WriteBatchOption) (writer.WriteResult, error) {
+	ctx, span := rw.tracer.Start(ctx, "sqlite.WriteBatch")
+	defer span.End()
+
+	if len(rows) == 0 {
+		return writer.WriteResult{}, nil
+	}
+
+	span.SetAttributes(attribute.Int("rows.count", len(rows)))
+	span.SetAttributes(attribute.String("db.operation", "insert_rows"))
+
+	if err := rw.migrate(ctx); err != nil {
+		span.RecordError(err)
+		return writer.WriteResult{}, err
+	}
+
+	tx, err := rw.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		return writer.WriteResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(
+		"INSERT INTO job_posts (%s) VALUES (%s) ON CONFLICT (link) DO NOTHING",
+		strings.Join(columns, ", "),
+		placeholders,
+	))
+	if err != nil {
+		span.RecordError(err)
+		return writer.WriteResult{}, fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	written := 0
+
+	var skipped []string
+
+	for i, row := range rows {
+		values, err := rowValues(row)
+		if err != nil {
+			span.RecordError(err)
+			return writer.WriteResult{}, fmt.Errorf("row %d: %w", i, err)
+		}
+
+		res, err := stmt.ExecContext(ctx, values...)
+		if err != nil {
+			span.RecordError(err)
+			return writer.WriteResult{}, fmt.Errorf("failed to insert row %d: %w", i, err)
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			span.RecordError(err)
+			return writer.WriteResult{}, fmt.Errorf("failed to count inserted row %d: %w", i, err)
+		}
+
+		if n == 0 {
+			skipped = append(skipped, values[linkColumn].(string))
+		}
+
+		written += int(n)
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		return writer.WriteResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	span.AddEvent("DataSuccessfullyAppended", trace.WithAttributes(attribute.Int("records.written", written)))
+
+	return writer.WriteResult{Skipped: skipped}, nil
+}
+
+func (rw *sqliteReadWriter) ClearBatch(ctx context.Context, _ ...writer.ClearBatchOption) error {
+	if err := rw.migrate(ctx); err != nil {
+		return err
+	}
+
+	_, err := rw.db.ExecContext(ctx, "DELETE FROM job_posts")
+
+	return err
+}
+
+func (rw *sqliteReadWriter) migrate(ctx context.Context) error {
+	rw.mtx.Lock()
+	defer rw.mtx.Unlock()
+
+	if rw.migrated {
+		return nil
+	}
+
+	if rw.empty {
+		return nil
+	}
+
+	var version int
+	if err := rw.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if rw.options.SkipMigrations {
+		rw.empty = version == 0
+		rw.migrated = true
+		return nil
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := rw.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin migration %d: %w", i+1, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %w", i+1, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %w", i+1, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", i+1, err)
+		}
+	}
+
+	rw.migrated = true
+
+	return nil
+}
+
+// Close releases the database handle.
+func (rw *sqliteReadWriter) Close() error {
+	return rw.db.Close()
+}
+
+func (rw *sqliteReadWriter) configure(path string) {
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path)
+
+	// a store opened as it is must not be created or converted to WAL either
+	if rw.options.SkipMigrations {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			rw.empty = true
+		}
+		dsn = fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&mode=ro", path)
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		detail := fmt.Sprintf("failed to open sqlite database at %s: %v", path, err)
+		panic(detail)
+	}
+
+	// sqlite allows a single writer, so serialize access through one connection
+	db.SetMaxOpenConns(1)
+
+	rw.db = db
+}
+
+func rowValues(row []any) ([]any, error) {
+	if len(row) > len(columns) {
+		return nil, fmt.Errorf("has %d cells but only %d columns are stored", len(row), len(columns))
+	}
+
+	values := make([]any, len(columns))
+
+	for i := range values {
+		if i < len(row) && row[i] != nil {
+			values[i] = fmt.Sprintf("%v", row[i])
+		} else {
+			values[i] = ""
+		}
+	}
+
+	return values, nil
+}
+
+func NewReadWriter(opts ...readwriter.Option) readwriter.ReadWriter {
+	options := readwriter.NewOptions(opts...)
+
+	rw := &sqliteReadWriter{
+		options: options,
+		tracer:  otel.Tracer("sqlite-readwriter"),
+	}
+
+	location := options.Location
+	if len(location) == 0 {
+		location = defaultLocation
+	}
+
+	rw.configure(location)
+
+	return rw
+}
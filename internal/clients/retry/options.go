@@ -0,0 +1,50 @@
+package retry
+
+import "time"
+
+type Option func(*Options)
+
+type Options struct {
+	// MaxAttempts counts the first try, so 1 disables retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func WithMaxAttempts(n int) Option {
+	return func(o *Options) {
+		o.MaxAttempts = n
+	}
+}
+
+func WithBaseDelay(d time.Duration) Option {
+	return func(o *Options) {
+		o.BaseDelay = d
+	}
+}
+
+// WithMaxDelay caps the computed backoff. A Retry-After longer than this ends
+// the retries instead of being waited out.
+func WithMaxDelay(d time.Duration) Option {
+	return func(o *Options) {
+		o.MaxDelay = d
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 1
+	}
+
+	return options
+}
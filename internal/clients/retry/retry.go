@@ -0,0 +1,66 @@
+package retry
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Backoff returns the full-jitter delay before the given retry, counting from 1.
+func Backoff(options Options, retry int) time.Duration {
+	ceiling := options.BaseDelay << (retry - 1)
+
+	if ceiling <= 0 || ceiling > options.MaxDelay {
+		ceiling = options.MaxDelay
+	}
+
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling) + 1
+}
+
+// RetryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func RetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if len(header) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(header); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// Sleep waits for d unless ctx ends first. It refuses outright when ctx's
+// deadline would pass before the wait is over.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,105 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+type transport struct {
+	options Options
+	base    http.RoundTripper
+}
+
+// RoundTrip retries idempotent requests that fail with a network error or a
+// retryable status. When the context cannot outlast the next wait, or the
+// server asks to wait longer than MaxDelay, the last response or error is
+// returned as is.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !replayable(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		rsp, err := t.base.RoundTrip(req)
+
+		if attempt >= t.options.MaxAttempts || !retryable(rsp, err) || ctx.Err() != nil {
+			return rsp, err
+		}
+
+		wait := Backoff(t.options, attempt)
+
+		if rsp != nil {
+			if after, ok := RetryAfter(rsp.Header.Get("Retry-After"), time.Now()); ok {
+				if after > t.options.MaxDelay {
+					return rsp, err
+				}
+				wait = after
+			}
+		}
+
+		if sleepErr := Sleep(ctx, wait); sleepErr != nil {
+			return rsp, err
+		}
+
+		if rsp != nil {
+			io.Copy(io.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
+	}
+}
+
+func replayable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	default:
+		return false
+	}
+}
+
+func retryable(rsp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	return RetryableStatus(rsp.StatusCode)
+}
+
+// RetryableStatus reports whether a response status is worth another attempt.
+func RetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewTransport wraps base, or http.DefaultTransport when base is nil, with retries.
+func NewTransport(base http.RoundTripper, opts ...Option) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{
+		options: NewOptions(opts...),
+		base:    base,
+	}
+}
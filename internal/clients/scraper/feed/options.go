@@ -0,0 +1,23 @@
+package feed
+
+import (
+	"context"
+
+	"github.com/w-h-a/scraper/internal/clients/cache"
+	"github.com/w-h-a/scraper/internal/clients/scraper"
+)
+
+type cacheKey struct{}
+
+// WithCache sets where feed validators (ETag and Last-Modified) are kept
+// between scrapes. Defaults to an in-memory cache.
+func WithCache(c cache.Cache) scraper.Option {
+	return func(o *scraper.Options) {
+		o.Context = context.WithValue(o.Context, cacheKey{}, c)
+	}
+}
+
+func getCacheFromCtx(ctx context.Context) (cache.Cache, bool) {
+	c, ok := ctx.Value(cacheKey{}).(cache.Cache)
+	return c, ok
+}
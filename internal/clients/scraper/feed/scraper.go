@@ -2,18 +2,152 @@ package feed
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
 
 	"github.com/mmcdole/gofeed"
+	"github.com/w-h-a/scraper/internal/clients/cache"
+	"github.com/w-h-a/scraper/internal/clients/cache/memory"
+	"github.com/w-h-a/scraper/internal/clients/retry"
 	"github.com/w-h-a/scraper/internal/clients/scraper"
 )
 
+func init() {
+	scraper.Register(scraper.Feed, NewScraper)
+}
+
+const cacheKeyPrefix = "feed-validators:"
+
+// validators are the response headers that let the next request be conditional.
+type validators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// feedScraper keeps the validators of a fresh response pending until Commit,
+// so that a feed whose items were never written is downloaded in full again.
 type feedScraper struct {
 	options scraper.Options
 	parser  *gofeed.Parser
+	client  *http.Client
+	cache   cache.Cache
+	pending map[string]validators
+	mtx     sync.Mutex
 }
 
+// Scrape fetches and parses a feed. When the server answers 304 Not Modified
+// to the cached validators, an empty feed is returned without parsing.
 func (s *feedScraper) Scrape(ctx context.Context, url string, _ ...scraper.ScrapeOption) (*gofeed.Feed, error) {
-	return s.parser.ParseURLWithContext(url, ctx)
+	s.mtx.Lock()
+	delete(s.pending, url)
+	s.mtx.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build feed request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", s.parser.UserAgent)
+
+	cached := s.lookup(ctx, url)
+
+	if len(cached.ETag) > 0 {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	if len(cached.LastModified) > 0 {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotModified {
+		slog.DebugContext(ctx, "feed not modified", "url", url)
+		return &gofeed.Feed{Link: url}, nil
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{
+			StatusCode: rsp.StatusCode,
+			Status:     rsp.Status,
+		}
+	}
+
+	feed, err := s.parser.Parse(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	v := validators{
+		ETag:         rsp.Header.Get("ETag"),
+		LastModified: rsp.Header.Get("Last-Modified"),
+	}
+
+	if len(v.ETag) > 0 || len(v.LastModified) > 0 {
+		s.mtx.Lock()
+		s.pending[url] = v
+		s.mtx.Unlock()
+	}
+
+	return feed, nil
+}
+
+// Commit stores the validators of the last scrape of url, once its items
+// have been written.
+func (s *feedScraper) Commit(ctx context.Context, url string) error {
+	s.mtx.Lock()
+	v, ok := s.pending[url]
+	delete(s.pending, url)
+	s.mtx.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return s.store(ctx, url, v)
+}
+
+// lookup treats the cache as best effort: a broken cache only costs a full
+// download.
+func (s *feedScraper) lookup(ctx context.Context, url string) validators {
+	var v validators
+
+	data, ok, err := s.cache.Get(ctx, cacheKeyPrefix+url)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to read feed validators", "url", url, "error", err)
+		return v
+	}
+
+	if !ok {
+		return v
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		slog.WarnContext(ctx, "failed to decode feed validators", "url", url, "error", err)
+		return validators{}
+	}
+
+	return v
+}
+
+func (s *feedScraper) store(ctx context.Context, url string, v validators) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode feed validators: %w", err)
+	}
+
+	if err := s.cache.Set(ctx, cacheKeyPrefix+url, data); err != nil {
+		return fmt.Errorf("failed to store feed validators: %w", err)
+	}
+
+	return nil
 }
 
 func NewScraper(opts ...scraper.Option) scraper.Scraper {
@@ -22,6 +156,13 @@ func NewScraper(opts ...scraper.Option) scraper.Scraper {
 	s := &feedScraper{
 		options: options,
 		parser:  gofeed.NewParser(),
+		client:  &http.Client{Transport: retry.NewTransport(nil, options.Retry...)},
+		cache:   memory.NewCache(),
+		pending: map[string]validators{},
+	}
+
+	if c, ok := getCacheFromCtx(options.Context); ok {
+		s.cache = c
 	}
 
 	return s
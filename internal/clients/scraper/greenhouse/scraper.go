@@ -0,0 +1,166 @@
+package greenhouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/w-h-a/scraper/internal/clients/retry"
+	"github.com/w-h-a/scraper/internal/clients/scraper"
+)
+
+func init() {
+	scraper.Register(scraper.Greenhouse, NewScraper)
+}
+
+const defaultBaseURL = "https://boards-api.greenhouse.io"
+
+type jobsResponse struct {
+	Jobs []job `json:"jobs"`
+}
+
+type job struct {
+	ID          int64        `json:"id"`
+	Title       string       `json:"title"`
+	AbsoluteURL string       `json:"absolute_url"`
+	UpdatedAt   string       `json:"updated_at"`
+	Location    location     `json:"location"`
+	Content     string       `json:"content"`
+	Departments []department `json:"departments"`
+}
+
+type location struct {
+	Name string `json:"name"`
+}
+
+type department struct {
+	Name string `json:"name"`
+}
+
+type greenhouseScraper struct {
+	options scraper.Options
+	baseURL string
+	client  *http.Client
+}
+
+// Scrape fetches the jobs of one Greenhouse board. The target is either a
+// board token or the full jobs URL of a board.
+func (s *greenhouseScraper) Scrape(ctx context.Context, target string, _ ...scraper.ScrapeOption) (*gofeed.Feed, error) {
+	endpoint, err := s.jobsURL(target)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build greenhouse request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{
+			StatusCode: rsp.StatusCode,
+			Status:     rsp.Status,
+		}
+	}
+
+	var body jobsResponse
+	if err := json.NewDecoder(rsp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode greenhouse jobs: %w", err)
+	}
+
+	feed := &gofeed.Feed{
+		Title: "Greenhouse",
+		Link:  endpoint,
+		Items: make([]*gofeed.Item, 0, len(body.Jobs)),
+	}
+
+	for _, j := range body.Jobs {
+		feed.Items = append(feed.Items, toItem(j))
+	}
+
+	return feed, nil
+}
+
+func (s *greenhouseScraper) jobsURL(target string) (string, error) {
+	target = strings.TrimSpace(target)
+
+	if len(target) == 0 {
+		return "", fmt.Errorf("greenhouse board token is required")
+	}
+
+	var endpoint *url.URL
+	var err error
+
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		endpoint, err = url.Parse(target)
+	} else {
+		endpoint, err = url.Parse(s.baseURL + "/v1/boards/" + url.PathEscape(target) + "/jobs")
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("invalid greenhouse board %q: %w", target, err)
+	}
+
+	// content is only included when asked for
+	query := endpoint.Query()
+	query.Set("content", "true")
+	endpoint.RawQuery = query.Encode()
+
+	return endpoint.String(), nil
+}
+
+func toItem(j job) *gofeed.Item {
+	item := &gofeed.Item{
+		Title:   j.Title,
+		Link:    j.AbsoluteURL,
+		GUID:    strconv.FormatInt(j.ID, 10),
+		Updated: j.UpdatedAt,
+		Content: html.UnescapeString(j.Content),
+		Custom:  map[string]string{},
+	}
+
+	if updated, err := time.Parse(time.RFC3339, j.UpdatedAt); err == nil {
+		item.UpdatedParsed = &updated
+	}
+
+	if len(j.Location.Name) > 0 {
+		item.Custom["location"] = j.Location.Name
+	}
+
+	for _, d := range j.Departments {
+		item.Categories = append(item.Categories, d.Name)
+	}
+
+	return item
+}
+
+func NewScraper(opts ...scraper.Option) scraper.Scraper {
+	options := scraper.NewOptions(opts...)
+
+	s := &greenhouseScraper{
+		options: options,
+		baseURL: defaultBaseURL,
+		client:  &http.Client{Transport: retry.NewTransport(nil, options.Retry...)},
+	}
+
+	if baseURL, ok := getBaseURLFromCtx(options.Context); ok {
+		s.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+
+	return s
+}
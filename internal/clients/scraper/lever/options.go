@@ -0,0 +1,21 @@
+package lever
+
+import (
+	"context"
+
+	"github.com/w-h-a/scraper/internal/clients/scraper"
+)
+
+type baseURLKey struct{}
+
+// WithBaseURL points the scraper at a different job board API host.
+func WithBaseURL(url string) scraper.Option {
+	return func(o *scraper.Options) {
+		o.Context = context.WithValue(o.Context, baseURLKey{}, url)
+	}
+}
+
+func getBaseURLFromCtx(ctx context.Context) (string, bool) {
+	url, ok := ctx.Value(baseURLKey{}).(string)
+	return url, ok
+}
@@ -0,0 +1,202 @@
+package lever
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/w-h-a/scraper/internal/clients/retry"
+	"github.com/w-h-a/scraper/internal/clients/scraper"
+)
+
+func init() {
+	scraper.Register(scraper.Lever, NewScraper)
+}
+
+const defaultBaseURL = "https://api.lever.co"
+
+type posting struct {
+	ID               string       `json:"id"`
+	Text             string       `json:"text"`
+	HostedURL        string       `json:"hostedUrl"`
+	CreatedAt        int64        `json:"createdAt"`
+	Categories       categories   `json:"categories"`
+	DescriptionPlain string       `json:"descriptionPlain"`
+	AdditionalPlain  string       `json:"additionalPlain"`
+	WorkplaceType    string       `json:"workplaceType"`
+	SalaryRange      *salaryRange `json:"salaryRange"`
+}
+
+type salaryRange struct {
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Currency string  `json:"currency"`
+	Interval string  `json:"interval"`
+}
+
+// periods maps Lever's pay intervals to the schema.org unitText values the
+// other sources use. One-time amounts have no period and are left out.
+var periods = map[string]string{
+	"per-hour-wage":    "HOUR",
+	"per-day-wage":     "DAY",
+	"per-week-salary":  "WEEK",
+	"per-month-salary": "MONTH",
+	"per-year-salary":  "YEAR",
+}
+
+type categories struct {
+	Team       string `json:"team"`
+	Department string `json:"department"`
+	Location   string `json:"location"`
+	Commitment string `json:"commitment"`
+}
+
+type leverScraper struct {
+	options scraper.Options
+	baseURL string
+	client  *http.Client
+}
+
+// Scrape fetches the published postings of one Lever company. The target is
+// either the company's Lever slug or the full postings URL.
+func (s *leverScraper) Scrape(ctx context.Context, target string, _ ...scraper.ScrapeOption) (*gofeed.Feed, error) {
+	endpoint, err := s.postingsURL(target)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build lever request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{
+			StatusCode: rsp.StatusCode,
+			Status:     rsp.Status,
+		}
+	}
+
+	var postings []posting
+	if err := json.NewDecoder(rsp.Body).Decode(&postings); err != nil {
+		return nil, fmt.Errorf("failed to decode lever postings: %w", err)
+	}
+
+	feed := &gofeed.Feed{
+		Title: "Lever",
+		Link:  endpoint,
+		Items: make([]*gofeed.Item, 0, len(postings)),
+	}
+
+	for _, p := range postings {
+		feed.Items = append(feed.Items, toItem(p))
+	}
+
+	return feed, nil
+}
+
+func (s *leverScraper) postingsURL(target string) (string, error) {
+	target = strings.TrimSpace(target)
+
+	if len(target) == 0 {
+		return "", fmt.Errorf("lever company is required")
+	}
+
+	var endpoint *url.URL
+	var err error
+
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		endpoint, err = url.Parse(target)
+	} else {
+		endpoint, err = url.Parse(s.baseURL + "/v0/postings/" + url.PathEscape(target))
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("invalid lever company %q: %w", target, err)
+	}
+
+	query := endpoint.Query()
+	query.Set("mode", "json")
+	endpoint.RawQuery = query.Encode()
+
+	return endpoint.String(), nil
+}
+
+func toItem(p posting) *gofeed.Item {
+	description := p.DescriptionPlain
+	if len(p.AdditionalPlain) > 0 {
+		description = strings.TrimSpace(description + "\n\n" + p.AdditionalPlain)
+	}
+
+	item := &gofeed.Item{
+		Title:       p.Text,
+		Link:        p.HostedURL,
+		GUID:        p.ID,
+		Description: description,
+		Custom:      map[string]string{},
+	}
+
+	if p.CreatedAt > 0 {
+		published := time.UnixMilli(p.CreatedAt)
+		item.PublishedParsed = &published
+		item.Published = published.Format(time.RFC3339)
+	}
+
+	if len(p.Categories.Team) > 0 {
+		item.Categories = append(item.Categories, p.Categories.Team)
+		item.Custom["team"] = p.Categories.Team
+	}
+
+	if len(p.Categories.Location) > 0 {
+		item.Custom["location"] = p.Categories.Location
+	}
+
+	if len(p.Categories.Commitment) > 0 {
+		item.Custom["commitment"] = p.Categories.Commitment
+	}
+
+	if len(p.WorkplaceType) > 0 {
+		item.Custom["workplace_type"] = p.WorkplaceType
+	}
+
+	if r := p.SalaryRange; r != nil && (r.Min > 0 || r.Max > 0) {
+		if period, ok := periods[r.Interval]; ok {
+			item.Custom["salary_min"] = strconv.FormatFloat(r.Min, 'f', -1, 64)
+			item.Custom["salary_max"] = strconv.FormatFloat(r.Max, 'f', -1, 64)
+			item.Custom["salary_currency"] = r.Currency
+			item.Custom["salary_period"] = period
+		}
+	}
+
+	return item
+}
+
+func NewScraper(opts ...scraper.Option) scraper.Scraper {
+	options := scraper.NewOptions(opts...)
+
+	s := &leverScraper{
+		options: options,
+		baseURL: defaultBaseURL,
+		client:  &http.Client{Transport: retry.NewTransport(nil, options.Retry...)},
+	}
+
+	if baseURL, ok := getBaseURLFromCtx(options.Context); ok {
+		s.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+
+	return s
+}
@@ -7,6 +7,12 @@ import (
 	"github.com/w-h-a/scraper/internal/clients/scraper"
 )
 
+func init() {
+	scraper.Register(scraper.Mock, func(opts ...scraper.Option) scraper.Scraper {
+		return NewScraper(opts...)
+	})
+}
+
 type mockScraper struct {
 	options      scraper.Options
 	feedToReturn *gofeed.Feed
@@ -21,7 +27,8 @@ func NewScraper(opts ...scraper.Option) *mockScraper {
 	options := scraper.NewOptions(opts...)
 
 	s := &mockScraper{
-		options: options,
+		options:      options,
+		feedToReturn: &gofeed.Feed{},
 	}
 
 	if fd, ok := getFeedFromCtx(options.Context); ok {
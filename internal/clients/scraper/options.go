@@ -1,13 +1,25 @@
 package scraper
 
-import "context"
+import (
+	"context"
+
+	"github.com/w-h-a/scraper/internal/clients/retry"
+)
 
 type Option func(*Options)
 
 type Options struct {
+	Retry   []retry.Option
 	Context context.Context
 }
 
+// WithRetry tunes how HTTP-backed scrapers retry transient failures.
+func WithRetry(opts ...retry.Option) Option {
+	return func(o *Options) {
+		o.Retry = append(o.Retry, opts...)
+	}
+}
+
 func NewOptions(opts ...Option) Options {
 	options := Options{
 		Context: context.Background(),
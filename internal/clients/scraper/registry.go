@@ -0,0 +1,49 @@
+package scraper
+
+import (
+	"fmt"
+	"sync"
+)
+
+type Factory func(opts ...Option) Scraper
+
+var (
+	factories = map[ScraperType]Factory{}
+	mtx       sync.RWMutex
+)
+
+// Register makes a scraper implementation available to New under the given type.
+// Implementations call it from an init function.
+func Register(t ScraperType, factory Factory) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if _, ok := factories[t]; ok {
+		panic(fmt.Sprintf("scraper %q already registered", t))
+	}
+
+	factories[t] = factory
+}
+
+// Registered reports whether an implementation is registered under the given type.
+func Registered(t ScraperType) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+
+	_, ok := factories[t]
+
+	return ok
+}
+
+// New builds the scraper registered under the given type.
+func New(t ScraperType, opts ...Option) (Scraper, error) {
+	mtx.RLock()
+	factory, ok := factories[t]
+	mtx.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no scraper registered for type %q", t)
+	}
+
+	return factory(opts...), nil
+}
@@ -9,17 +9,19 @@ import (
 type ScraperType string
 
 const (
-	Mock ScraperType = "mock"
-	Feed ScraperType = "feed"
-)
-
-var (
-	ScraperTypes = map[string]ScraperType{
-		"mock": Mock,
-		"feed": Feed,
-	}
+	Mock       ScraperType = "mock"
+	Feed       ScraperType = "feed"
+	Greenhouse ScraperType = "greenhouse"
+	Lever      ScraperType = "lever"
 )
 
 type Scraper interface {
 	Scrape(ctx context.Context, url string, opts ...ScrapeOption) (*gofeed.Feed, error)
 }
+
+// Committer is implemented by scrapers that hold back state, like the
+// validators for a conditional request, until the caller has stored what a
+// scrape returned. Uncommitted state is dropped by the next scrape of url.
+type Committer interface {
+	Commit(ctx context.Context, url string) error
+}
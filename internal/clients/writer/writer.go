@@ -1,8 +1,34 @@
 package writer
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type Writer interface {
-	WriteBatch(ctx context.Context, rows [][]any, opts ...WriteBatchOption) error
+	WriteBatch(ctx context.Context, rows [][]any, opts ...WriteBatchOption) (WriteResult, error)
 	ClearBatch(ctx context.Context, opts ...ClearBatchOption) error
 }
+
+// WriteResult reports what a batch stored.
+type WriteResult struct {
+	// Skipped lists the links of rows left out because the store already
+	// held them, e.g. through a unique constraint on the link.
+	Skipped []string
+}
+
+// PartialWriteError reports a batch that failed after some of its rows were
+// already stored. Rows are written in order, so the first Written rows landed.
+type PartialWriteError struct {
+	Written int
+	Total   int
+	Err     error
+}
+
+func (e *PartialWriteError) Error() string {
+	return fmt.Sprintf("wrote %d of %d rows: %v", e.Written, e.Total, e.Err)
+}
+
+func (e *PartialWriteError) Unwrap() error {
+	return e.Err
+}
@@ -2,8 +2,14 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/w-h-a/scraper/internal/clients/cache"
+	"github.com/w-h-a/scraper/internal/clients/enricher"
+	"github.com/w-h-a/scraper/internal/clients/notifier"
+	"github.com/w-h-a/scraper/internal/clients/outbox"
 	"github.com/w-h-a/scraper/internal/clients/readwriter"
 	"github.com/w-h-a/scraper/internal/clients/scraper"
 )
@@ -19,10 +25,34 @@ type config struct {
 	version                     string
 	logsAddress                 string
 	tracesAddress               string
+	metricsAddress              string
+	metricsPrometheus           bool
 	scraper                     string
+	scraperMaxAttempts          int
 	readwriter                  string
 	readwriterLocation          string
 	sheetsServiceAccountKeyPath string
+	sheetsChunkSize             int
+	feedsPath                   string
+	feedCache                   string
+	feedCacheLocation           string
+	enricher                    string
+	filtersPath                 string
+	canonicalRulesPath          string
+	tagsPath                    string
+	dedupMode                   string
+	dedupThreshold              float64
+	descriptionLimit            int
+	notifier                    string
+	notifierLocation            string
+	outbox                      string
+	outboxLocation              string
+	schedule                    string
+	scheduleTimezone            string
+	scheduleJitter              time.Duration
+	adminAddress                string
+	breakerThreshold            int
+	breakerCooldown             time.Duration
 }
 
 func New() {
@@ -33,10 +63,34 @@ func New() {
 			version:                     "0.1.0-alpha.0",
 			logsAddress:                 "",
 			tracesAddress:               "",
+			metricsAddress:              "",
+			metricsPrometheus:           false,
 			scraper:                     "feed",
+			scraperMaxAttempts:          3,
 			readwriter:                  "sheets",
 			readwriterLocation:          "",
 			sheetsServiceAccountKeyPath: "service_account_key.json",
+			sheetsChunkSize:             500,
+			feedsPath:                   "",
+			feedCache:                   "memory",
+			feedCacheLocation:           "",
+			enricher:                    "",
+			filtersPath:                 "",
+			canonicalRulesPath:          "",
+			tagsPath:                    "",
+			dedupMode:                   "",
+			dedupThreshold:              0.8,
+			descriptionLimit:            45000,
+			notifier:                    "",
+			notifierLocation:            "",
+			outbox:                      "",
+			outboxLocation:              "",
+			schedule:                    "0 8 * * *",
+			scheduleTimezone:            "UTC",
+			scheduleJitter:              0,
+			adminAddress:                ":8080",
+			breakerThreshold:            3,
+			breakerCooldown:             24 * time.Hour,
 		}
 
 		env := os.Getenv("ENV")
@@ -64,18 +118,41 @@ func New() {
 			instance.tracesAddress = tracesAddress
 		}
 
+		metricsAddress := os.Getenv("METRICS_ADDRESS")
+		if len(metricsAddress) > 0 {
+			instance.metricsAddress = metricsAddress
+		}
+
+		metricsPrometheus := os.Getenv("METRICS_PROMETHEUS")
+		if len(metricsPrometheus) > 0 {
+			enabled, err := strconv.ParseBool(metricsPrometheus)
+			if err != nil {
+				panic("invalid metrics prometheus flag")
+			}
+			instance.metricsPrometheus = enabled
+		}
+
 		s := os.Getenv("SCRAPER")
 		if len(s) > 0 {
-			if _, ok := scraper.ScraperTypes[s]; ok {
+			if scraper.Registered(scraper.ScraperType(s)) {
 				instance.scraper = s
 			} else {
 				panic("unsupported scraper")
 			}
 		}
 
+		scraperMaxAttempts := os.Getenv("SCRAPER_MAX_ATTEMPTS")
+		if len(scraperMaxAttempts) > 0 {
+			attempts, err := strconv.Atoi(scraperMaxAttempts)
+			if err != nil || attempts < 1 {
+				panic("invalid scraper max attempts")
+			}
+			instance.scraperMaxAttempts = attempts
+		}
+
 		rw := os.Getenv("READ_WRITER")
 		if len(rw) > 0 {
-			if _, ok := readwriter.ReadWriterTypes[rw]; ok {
+			if readwriter.Registered(readwriter.ReadWriterType(rw)) {
 				instance.readwriter = rw
 			} else {
 				panic("unsupported readwriter")
@@ -91,6 +168,153 @@ func New() {
 		if len(sheetsServiceAccountKeyPath) > 0 {
 			instance.sheetsServiceAccountKeyPath = sheetsServiceAccountKeyPath
 		}
+
+		sheetsChunkSize := os.Getenv("SHEETS_CHUNK_SIZE")
+		if len(sheetsChunkSize) > 0 {
+			size, err := strconv.Atoi(sheetsChunkSize)
+			if err != nil || size < 1 {
+				panic("invalid sheets chunk size")
+			}
+			instance.sheetsChunkSize = size
+		}
+
+		feedsPath := os.Getenv("FEEDS_PATH")
+		if len(feedsPath) > 0 {
+			instance.feedsPath = feedsPath
+		}
+
+		fc := os.Getenv("FEED_CACHE")
+		if len(fc) > 0 {
+			if cache.Registered(cache.CacheType(fc)) {
+				instance.feedCache = fc
+			} else {
+				panic("unsupported feed cache")
+			}
+		}
+
+		feedCacheLocation := os.Getenv("FEED_CACHE_LOCATION")
+		if len(feedCacheLocation) > 0 {
+			instance.feedCacheLocation = feedCacheLocation
+		}
+
+		e := os.Getenv("ENRICHER")
+		if len(e) > 0 {
+			if enricher.Registered(enricher.EnricherType(e)) {
+				instance.enricher = e
+			} else {
+				panic("unsupported enricher")
+			}
+		}
+
+		filtersPath := os.Getenv("FILTERS_PATH")
+		if len(filtersPath) > 0 {
+			instance.filtersPath = filtersPath
+		}
+
+		canonicalRulesPath := os.Getenv("CANONICAL_RULES_PATH")
+		if len(canonicalRulesPath) > 0 {
+			instance.canonicalRulesPath = canonicalRulesPath
+		}
+
+		tagsPath := os.Getenv("TAGS_PATH")
+		if len(tagsPath) > 0 {
+			instance.tagsPath = tagsPath
+		}
+
+		descriptionLimit := os.Getenv("DESCRIPTION_LIMIT")
+		if len(descriptionLimit) > 0 {
+			limit, err := strconv.Atoi(descriptionLimit)
+			if err != nil || limit < 0 {
+				panic("invalid description limit")
+			}
+			instance.descriptionLimit = limit
+		}
+
+		dedupMode := os.Getenv("DEDUP_MODE")
+		if len(dedupMode) > 0 {
+			instance.dedupMode = dedupMode
+		}
+
+		dedupThreshold := os.Getenv("DEDUP_THRESHOLD")
+		if len(dedupThreshold) > 0 {
+			threshold, err := strconv.ParseFloat(dedupThreshold, 64)
+			if err != nil || threshold < 0 || threshold > 1 {
+				panic("invalid dedup threshold")
+			}
+			instance.dedupThreshold = threshold
+		}
+
+		n := os.Getenv("NOTIFIER")
+		if len(n) > 0 {
+			if notifier.Registered(notifier.NotifierType(n)) {
+				instance.notifier = n
+			} else {
+				panic("unsupported notifier")
+			}
+		}
+
+		notifierLocation := os.Getenv("NOTIFIER_LOCATION")
+		if len(notifierLocation) > 0 {
+			instance.notifierLocation = notifierLocation
+		} else if len(instance.notifier) > 0 {
+			panic("notifier location is required")
+		}
+
+		ob := os.Getenv("OUTBOX")
+		if len(ob) > 0 {
+			if outbox.Registered(outbox.OutboxType(ob)) {
+				instance.outbox = ob
+			} else {
+				panic("unsupported outbox")
+			}
+		}
+
+		outboxLocation := os.Getenv("OUTBOX_LOCATION")
+		if len(outboxLocation) > 0 {
+			instance.outboxLocation = outboxLocation
+		}
+
+		schedule := os.Getenv("SCHEDULE")
+		if len(schedule) > 0 {
+			instance.schedule = schedule
+		}
+
+		scheduleTimezone := os.Getenv("SCHEDULE_TIMEZONE")
+		if len(scheduleTimezone) > 0 {
+			instance.scheduleTimezone = scheduleTimezone
+		}
+
+		scheduleJitter := os.Getenv("SCHEDULE_JITTER")
+		if len(scheduleJitter) > 0 {
+			jitter, err := time.ParseDuration(scheduleJitter)
+			if err != nil {
+				panic("invalid schedule jitter")
+			}
+			instance.scheduleJitter = jitter
+		}
+
+		adminAddress := os.Getenv("ADMIN_ADDRESS")
+		if len(adminAddress) > 0 {
+			instance.adminAddress = adminAddress
+		}
+
+		breakerThreshold := os.Getenv("CIRCUIT_BREAKER_THRESHOLD")
+		if len(breakerThreshold) > 0 {
+			threshold, err := strconv.Atoi(breakerThreshold)
+			if err != nil || threshold < 0 {
+				panic("invalid circuit breaker threshold")
+			}
+			instance.breakerThreshold = threshold
+		}
+
+		breakerCooldown := os.Getenv("CIRCUIT_BREAKER_COOLDOWN")
+		if len(breakerCooldown) > 0 {
+			cooldown, err := time.ParseDuration(breakerCooldown)
+			if err != nil || cooldown < 0 {
+				panic("invalid circuit breaker cooldown")
+			}
+			instance.breakerCooldown = cooldown
+		}
 	})
 }
 
@@ -134,6 +358,22 @@ func TracesAddress() string {
 	return instance.tracesAddress
 }
 
+func MetricsAddress() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.metricsAddress
+}
+
+func MetricsPrometheus() bool {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.metricsPrometheus
+}
+
 func Scraper() string {
 	if instance == nil {
 		panic("cfg is nil")
@@ -142,6 +382,14 @@ func Scraper() string {
 	return instance.scraper
 }
 
+func ScraperMaxAttempts() int {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.scraperMaxAttempts
+}
+
 func ReadWriter() string {
 	if instance == nil {
 		panic("cfg is nil")
@@ -165,3 +413,171 @@ func SheetsServiceAccountPath() string {
 
 	return instance.sheetsServiceAccountKeyPath
 }
+
+func SheetsChunkSize() int {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.sheetsChunkSize
+}
+
+func FeedsPath() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.feedsPath
+}
+
+func FeedCache() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.feedCache
+}
+
+func FeedCacheLocation() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.feedCacheLocation
+}
+
+func Enricher() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.enricher
+}
+
+func FiltersPath() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.filtersPath
+}
+
+func DescriptionLimit() int {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.descriptionLimit
+}
+
+func DedupMode() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.dedupMode
+}
+
+func DedupThreshold() float64 {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.dedupThreshold
+}
+
+func CanonicalRulesPath() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.canonicalRulesPath
+}
+
+func TagsPath() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.tagsPath
+}
+
+func Notifier() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.notifier
+}
+
+func NotifierLocation() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.notifierLocation
+}
+
+func Outbox() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.outbox
+}
+
+func OutboxLocation() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.outboxLocation
+}
+
+func Schedule() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.schedule
+}
+
+func ScheduleTimezone() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.scheduleTimezone
+}
+
+func ScheduleJitter() time.Duration {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.scheduleJitter
+}
+
+func AdminAddress() string {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.adminAddress
+}
+
+func CircuitBreakerThreshold() int {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.breakerThreshold
+}
+
+func CircuitBreakerCooldown() time.Duration {
+	if instance == nil {
+		panic("cfg is nil")
+	}
+
+	return instance.breakerCooldown
+}
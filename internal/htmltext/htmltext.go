@@ -0,0 +1,299 @@
+// Package htmltext turns job description HTML into readable plain text.
+package htmltext
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// TruncationMarker ends text that was cut to fit a limit.
+const TruncationMarker = "\n\n[truncated]"
+
+// skipped elements never carry visible text.
+var skipped = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Head:     true,
+	atom.Iframe:   true,
+	atom.Svg:      true,
+	atom.Object:   true,
+}
+
+// paragraphs are block elements set apart by a blank line.
+var paragraphs = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true,
+	atom.Header: true, atom.Footer: true, atom.Aside: true, atom.Main: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Ul: true, atom.Ol: true, atom.Dl: true, atom.Table: true,
+	atom.Blockquote: true, atom.Pre: true, atom.Hr: true, atom.Figure: true,
+}
+
+// lines are elements that start on a line of their own.
+var lines = map[atom.Atom]bool{
+	atom.Br: true, atom.Tr: true, atom.Dt: true, atom.Dd: true, atom.Caption: true,
+}
+
+var tagPattern = regexp.MustCompile(`<[a-zA-Z!/]`)
+
+// Convert returns the visible text of s. Paragraphs are separated by blank
+// lines, list items start with "- " or their number, entities are decoded
+// and scripts and styles are dropped. Text without markup only has its
+// entities decoded and its whitespace tidied.
+func Convert(s string) string {
+	if !tagPattern.MatchString(s) {
+		return tidy(html.UnescapeString(s))
+	}
+
+	c := &converter{}
+	c.run(s)
+
+	return tidy(c.b.String())
+}
+
+type list struct {
+	ordered bool
+	next    int
+}
+
+type converter struct {
+	b     strings.Builder
+	skip  int
+	pre   int
+	lists []list
+}
+
+func (c *converter) run(s string) {
+	z := nethtml.NewTokenizer(strings.NewReader(s))
+
+	for {
+		tt := z.Next()
+
+		switch tt {
+		case nethtml.ErrorToken:
+			return
+		case nethtml.TextToken:
+			if c.skip == 0 {
+				c.text(string(z.Text()))
+			}
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			a := atom.Lookup(name)
+
+			if skipped[a] {
+				if tt == nethtml.StartTagToken {
+					c.skip++
+				}
+				continue
+			}
+
+			if c.skip > 0 {
+				continue
+			}
+
+			c.open(a, tt == nethtml.SelfClosingTagToken, z, hasAttr)
+		case nethtml.EndTagToken:
+			name, _ := z.TagName()
+			a := atom.Lookup(name)
+
+			if skipped[a] {
+				if c.skip > 0 {
+					c.skip--
+				}
+				continue
+			}
+
+			if c.skip > 0 {
+				continue
+			}
+
+			c.close(a)
+		}
+	}
+}
+
+func (c *converter) open(a atom.Atom, selfClosing bool, z *nethtml.Tokenizer, hasAttr bool) {
+	switch {
+	case a == atom.Li:
+		c.newline()
+		c.b.WriteString(c.bullet())
+	case a == atom.Ul || a == atom.Ol:
+		c.paragraph()
+		if !selfClosing {
+			c.lists = append(c.lists, list{ordered: a == atom.Ol, next: start(z, hasAttr)})
+		}
+	case a == atom.Td || a == atom.Th:
+		c.space()
+	case a == atom.Img:
+		if alt := attr(z, hasAttr, "alt"); len(alt) > 0 {
+			c.text(alt)
+		}
+	case paragraphs[a]:
+		c.paragraph()
+		if a == atom.Pre && !selfClosing {
+			c.pre++
+		}
+	case lines[a]:
+		c.newline()
+	}
+}
+
+func (c *converter) close(a atom.Atom) {
+	switch {
+	case a == atom.Ul || a == atom.Ol:
+		if len(c.lists) > 0 {
+			c.lists = c.lists[:len(c.lists)-1]
+		}
+		c.paragraph()
+	case a == atom.Li:
+		c.newline()
+	case paragraphs[a]:
+		if a == atom.Pre && c.pre > 0 {
+			c.pre--
+		}
+		c.paragraph()
+	case lines[a]:
+		c.newline()
+	}
+}
+
+func (c *converter) text(s string) {
+	if c.pre > 0 {
+		c.b.WriteString(s)
+		return
+	}
+
+	// whitespace between inline runs collapses to a single space
+	collapsed := strings.Join(strings.Fields(s), " ")
+
+	if len(collapsed) == 0 {
+		if len(s) > 0 {
+			c.space()
+		}
+		return
+	}
+
+	if r, _ := utf8.DecodeRuneInString(s); unicode.IsSpace(r) {
+		c.space()
+	}
+
+	c.b.WriteString(collapsed)
+
+	if r, _ := utf8.DecodeLastRuneInString(s); unicode.IsSpace(r) {
+		c.space()
+	}
+}
+
+func (c *converter) bullet() string {
+	if len(c.lists) == 0 {
+		return "- "
+	}
+
+	l := &c.lists[len(c.lists)-1]
+	indent := strings.Repeat("  ", len(c.lists)-1)
+
+	if !l.ordered {
+		return indent + "- "
+	}
+
+	n := l.next
+	l.next++
+
+	return indent + strconv.Itoa(n) + ". "
+}
+
+func (c *converter) space() {
+	if c.b.Len() == 0 {
+		return
+	}
+	if r, _ := utf8.DecodeLastRuneInString(c.b.String()); !unicode.IsSpace(r) {
+		c.b.WriteByte(' ')
+	}
+}
+
+// newline and paragraph only top up the breaks already written, so nested
+// and adjacent blocks do not stack blank lines.
+func (c *converter) newline() {
+	if c.trailingNewlines() < 1 {
+		c.b.WriteByte('\n')
+	}
+}
+
+func (c *converter) paragraph() {
+	for n := c.trailingNewlines(); n < 2; n++ {
+		c.b.WriteByte('\n')
+	}
+}
+
+func (c *converter) trailingNewlines() int {
+	s := strings.TrimRight(c.b.String(), " \t")
+	if len(s) == 0 {
+		// nothing written yet, so no break is needed
+		return 2
+	}
+	return len(s) - len(strings.TrimRight(s, "\n"))
+}
+
+func start(z *nethtml.Tokenizer, hasAttr bool) int {
+	if n, err := strconv.Atoi(attr(z, hasAttr, "start")); err == nil {
+		return n
+	}
+	return 1
+}
+
+func attr(z *nethtml.Tokenizer, hasAttr bool, name string) string {
+	for hasAttr {
+		var key, val []byte
+		key, val, hasAttr = z.TagAttr()
+		if string(key) == name {
+			return string(val)
+		}
+	}
+	return ""
+}
+
+// tidy trims every line, apart from list indentation, keeps at most one blank line in a row and trims
+// the whole text. Non-breaking spaces count as spaces.
+func tidy(s string) string {
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	var out []string
+	blank := false
+
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		// keep list indentation, drop the rest
+		if trimmed := strings.TrimLeftFunc(line, unicode.IsSpace); !isListItem(trimmed) {
+			line = trimmed
+		}
+
+		if len(line) == 0 {
+			blank = len(out) > 0
+			continue
+		}
+
+		if blank {
+			out = append(out, "")
+			blank = false
+		}
+
+		out = append(out, line)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+var listItemPattern = regexp.MustCompile(`^(?:- |\d+\. )`)
+
+func isListItem(line string) bool {
+	return listItemPattern.MatchString(line)
+}
@@ -0,0 +1,64 @@
+package htmltext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Truncate cuts text to at most limit characters, marker included, and
+// reports whether it did. The cut falls after the last sentence or
+// paragraph that fits, or at a word boundary when no sentence ends in the
+// second half of the allowed text. A limit of zero or less disables it.
+func Truncate(text string, limit int) (string, bool) {
+	runes := []rune(text)
+	if limit <= 0 || len(runes) <= limit {
+		return text, false
+	}
+
+	keep := limit - len([]rune(TruncationMarker))
+	if keep <= 0 {
+		return string(runes[:limit]), true
+	}
+
+	cut := sentenceEnd(runes[:keep+1])
+	if cut < keep/2 {
+		cut = wordEnd(runes[:keep+1])
+	}
+	if cut <= 0 {
+		cut = keep
+	}
+
+	kept := strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace)
+
+	return kept + TruncationMarker, true
+}
+
+// sentenceEnd returns the length of the longest prefix ending a sentence or
+// a paragraph, or 0. The last rune is only look-ahead.
+func sentenceEnd(runes []rune) int {
+	for i := len(runes) - 2; i >= 0; i-- {
+		next := runes[i+1]
+
+		switch runes[i] {
+		case '.', '!', '?', '…':
+			if unicode.IsSpace(next) {
+				return i + 1
+			}
+		case '\n':
+			if next == '\n' {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// wordEnd returns the length of the longest prefix ending before whitespace, or 0.
+func wordEnd(runes []rune) int {
+	for i := len(runes) - 1; i > 0; i-- {
+		if unicode.IsSpace(runes[i]) && !unicode.IsSpace(runes[i-1]) {
+			return i
+		}
+	}
+	return 0
+}
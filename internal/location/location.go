@@ -0,0 +1,215 @@
+// Package location works out where a job can be done from: remote, hybrid or
+// onsite, the countries or regions it is open to and the time zones it
+// expects candidates to work in.
+package location
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Workplace values.
+const (
+	Remote = "remote"
+	Hybrid = "hybrid"
+	Onsite = "onsite"
+)
+
+// Fields are the parts of a job that location is read from. Location,
+// Workplace and Regions are structured values given by the source and are
+// trusted over the free text of the title and description.
+type Fields struct {
+	Title       string
+	Description string
+	Location    string
+	Workplace   string
+	Regions     string
+}
+
+// Result is the classification of a job. Regions holds ISO 3166 country
+// codes and region names such as EMEA; Timezone is a span of UTC offsets
+// such as "UTC-3..UTC+3". Empty values mean the job does not say.
+type Result struct {
+	Workplace string
+	Regions   []string
+	Timezone  string
+}
+
+// Classify reads the workplace, regions and time zone of a job. Places are
+// taken from the title and structured fields wherever they appear, but from
+// the description only where it restricts who can apply, e.g. "US only" or
+// "must be based in the EU", since descriptions also name offices and
+// customers.
+func Classify(f Fields) Result {
+	return Result{
+		Workplace: classifyWorkplace(f),
+		Regions:   classifyRegions(f),
+		Timezone:  findTimezone(strings.Join([]string{f.Title, f.Location, f.Regions, f.Description}, "\n")),
+	}
+}
+
+var structuredWorkplaces = map[string]string{
+	"remote":      Remote,
+	"telecommute": Remote,
+	"hybrid":      Hybrid,
+	"onsite":      Onsite,
+	"on-site":     Onsite,
+	"on site":     Onsite,
+	"office":      Onsite,
+	"in-office":   Onsite,
+}
+
+var (
+	notRemote = regexp.MustCompile(`(?i)\b(?:not|no|non)[\s-]+(?:a\s+|fully\s+)?remote\b|\bremote\s+(?:work\s+)?(?:is\s+)?not\s+(?:possible|available|offered|an\s+option)`)
+	hybrid    = regexp.MustCompile(`(?i)\bhybrid\b(?:[\s-]+(\pL+))?|\b(?:partially|partly)\s+remote\b|\b\d\s+days?\s+(?:a|per)\s+week\s+(?:in|at)\s+(?:the|our)?\s*office\b`)
+	remote    = regexp.MustCompile(`(?i)\bremote\b|\bwork(?:ing)?\s+from\s+(?:home|anywhere)\b|\bwfh\b|\btelecommut\w*|\bfully\s+distributed\b`)
+	onsite    = regexp.MustCompile(`(?i)\bon[\s-]?site\b|\bin[\s-]office\b|\boffice[\s-]based\b|\bin[\s-]person\b`)
+)
+
+// hybridNouns follow "hybrid" when it describes technology, not the workplace.
+var hybridNouns = map[string]bool{
+	"cloud": true, "clouds": true, "app": true, "apps": true, "mobile": true,
+	"search": true, "infrastructure": true, "environment": true, "environments": true,
+	"architecture": true, "systems": true, "deployments": true, "databases": true,
+}
+
+func classifyWorkplace(f Fields) string {
+	if workplace, ok := structuredWorkplaces[strings.ToLower(strings.TrimSpace(f.Workplace))]; ok {
+		return workplace
+	}
+
+	for _, text := range []string{f.Title, f.Location, f.Description} {
+		if workplace := workplaceIn(text); len(workplace) > 0 {
+			return workplace
+		}
+	}
+
+	// a structured location that names no arrangement is an office
+	if codes := locationPlaces(f.Location); len(codes) > 0 && !slices.Contains(codes, Worldwide) {
+		return Onsite
+	}
+
+	return ""
+}
+
+func workplaceIn(text string) string {
+	if notRemote.MatchString(text) {
+		return Onsite
+	}
+
+	for _, m := range hybrid.FindAllStringSubmatch(text, -1) {
+		if !hybridNouns[strings.ToLower(m[1])] {
+			return Hybrid
+		}
+	}
+
+	if remote.MatchString(text) {
+		return Remote
+	}
+
+	if onsite.MatchString(text) {
+		return Onsite
+	}
+
+	return ""
+}
+
+// locationPlaces returns the places in a structured location, reading a
+// trailing US state code when no place is named, as in "Springfield, IL".
+func locationPlaces(text string) []string {
+	var codes []string
+
+	for _, p := range findPlaces(text) {
+		codes = append(codes, p.code)
+	}
+
+	if len(codes) == 0 && usStates.MatchString(text) {
+		codes = append(codes, "US")
+	}
+
+	return codes
+}
+
+// restrictionBefore introduces the places a role is restricted to, as in
+// "remote (EMEA)", "based in Germany" or "open to candidates in Canada".
+var restrictionBefore = regexp.MustCompile(`(?i)\bremote\b\s*(?:[-–—:(,/|]|\bin\b|\bwithin\b|\bfrom\b|\bacross\b)|` +
+	`\b(?:based|located|living|reside|residing|resident|residents|citizens?|work(?:ing)?|hire|hiring|candidates|applicants|eligible|authori[sz]ed\s+to\s+work)\s+(?:\w+\s+){0,2}?(?:in|from|within|across)\b|` +
+	`\b(?:only|anywhere)\s+in\b|\bopen\s+to\b`)
+
+// restrictionAfter follows a place the role is restricted to, as in
+// "US only", "EU-based" or "EMEA time zones".
+var restrictionAfter = regexp.MustCompile(`(?i)^\)?\s*[-–]?\s*\(?(?:only|based|residents?|citizens?|time\s?-?zones?|hours|remote)\b`)
+
+// clauseEnd bounds how far a restriction reaches.
+var clauseEnd = regexp.MustCompile(`[.;!?\n]\s|\n|$`)
+
+func classifyRegions(f Fields) []string {
+	var codes []string
+
+	for _, p := range findPlaces(f.Regions) {
+		codes = append(codes, p.code)
+	}
+
+	codes = append(codes, locationPlaces(f.Location)...)
+
+	for _, p := range findPlaces(f.Title) {
+		codes = append(codes, p.code)
+	}
+
+	codes = append(codes, restrictedPlaces(f.Description)...)
+
+	return normalizeRegions(codes)
+}
+
+// restrictedPlaces returns the places a description restricts the role to.
+func restrictedPlaces(text string) []string {
+	var codes []string
+
+	found := findPlaces(text)
+
+	for _, p := range found {
+		if restrictionAfter.MatchString(text[p.end:]) {
+			codes = append(codes, p.code)
+		}
+	}
+
+	for _, m := range restrictionBefore.FindAllStringIndex(text, -1) {
+		end := m[1] + clauseEnd.FindStringIndex(text[m[1]:])[0]
+
+		for _, p := range found {
+			if p.start >= m[1] && p.end <= end {
+				codes = append(codes, p.code)
+			}
+		}
+	}
+
+	return codes
+}
+
+// normalizeRegions drops repeats, keeping the order places were found in,
+// and drops Worldwide when the job also names somewhere specific.
+func normalizeRegions(codes []string) []string {
+	seen := map[string]bool{}
+
+	var regions []string
+	worldwide := false
+
+	for _, code := range codes {
+		if code == Worldwide {
+			worldwide = true
+			continue
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		regions = append(regions, code)
+	}
+
+	if len(regions) == 0 && worldwide {
+		return []string{Worldwide}
+	}
+
+	return regions
+}
@@ -0,0 +1,183 @@
+package location
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Regions that are not a single country.
+const (
+	Worldwide    = "Worldwide"
+	Europe       = "Europe"
+	EU           = "EU"
+	EMEA         = "EMEA"
+	APAC         = "APAC"
+	LATAM        = "LATAM"
+	NorthAmerica = "North America"
+	Americas     = "Americas"
+)
+
+// places maps each region, or the ISO 3166 code of each country, to the
+// names it goes by. Cities are listed under their country so that a
+// structured location such as "Berlin" still resolves.
+var places = map[string][]string{
+	Worldwide:    {"worldwide", "anywhere", "globally", "any location", "any country"},
+	Europe:       {"europe", "european"},
+	EU:           {"EU", "european union"},
+	EMEA:         {"EMEA"},
+	APAC:         {"APAC", "asia pacific", "asia-pacific"},
+	LATAM:        {"LATAM", "latin america", "south america"},
+	NorthAmerica: {"north america", "NA", "NORAM", "NAMER"},
+	Americas:     {"americas", "AMER"},
+
+	"US": {"united states", "united states of america", "US", "USA", "U.S.", "U.S.A.", "america",
+		"san francisco", "new york", "NYC", "seattle", "austin", "boston", "chicago", "los angeles", "denver", "atlanta", "miami", "portland"},
+	"CA": {"canada", "canadian", "toronto", "vancouver", "montreal", "montréal", "ottawa"},
+	"MX": {"mexico", "méxico", "mexico city"},
+	"BR": {"brazil", "brasil", "são paulo", "sao paulo"},
+	"AR": {"argentina", "buenos aires"},
+	"CO": {"colombia", "bogotá", "bogota"},
+	"GB": {"united kingdom", "UK", "U.K.", "great britain", "england", "scotland", "wales", "london", "manchester", "edinburgh", "bristol"},
+	"IE": {"ireland", "dublin"},
+	"DE": {"germany", "deutschland", "berlin", "munich", "münchen", "hamburg", "frankfurt", "cologne"},
+	"FR": {"france", "paris", "lyon"},
+	"NL": {"netherlands", "the netherlands", "holland", "amsterdam", "rotterdam"},
+	"BE": {"belgium", "brussels"},
+	"ES": {"spain", "madrid", "barcelona"},
+	"PT": {"portugal", "lisbon", "porto"},
+	"IT": {"italy", "milan", "rome"},
+	"CH": {"switzerland", "zurich", "zürich", "geneva"},
+	"AT": {"austria", "vienna"},
+	"SE": {"sweden", "stockholm"},
+	"NO": {"norway", "oslo"},
+	"DK": {"denmark", "copenhagen"},
+	"FI": {"finland", "helsinki"},
+	"PL": {"poland", "warsaw", "kraków", "krakow"},
+	"CZ": {"czech republic", "czechia", "prague"},
+	"RO": {"romania", "bucharest"},
+	"UA": {"ukraine", "kyiv"},
+	"EE": {"estonia", "tallinn"},
+	"GR": {"greece", "athens"},
+	"TR": {"turkey", "türkiye", "istanbul"},
+	"IL": {"israel", "tel aviv"},
+	"AE": {"united arab emirates", "UAE", "dubai"},
+	"ZA": {"south africa", "cape town", "johannesburg"},
+	"NG": {"nigeria", "lagos"},
+	"KE": {"kenya", "nairobi"},
+	"EG": {"egypt", "cairo"},
+	"IN": {"india", "bangalore", "bengaluru", "mumbai", "hyderabad", "pune", "delhi"},
+	"SG": {"singapore"},
+	"JP": {"japan", "tokyo"},
+	"KR": {"south korea", "korea", "seoul"},
+	"CN": {"china", "beijing", "shanghai", "shenzhen"},
+	"HK": {"hong kong"},
+	"TW": {"taiwan", "taipei"},
+	"PH": {"philippines", "manila"},
+	"VN": {"vietnam", "viet nam"},
+	"ID": {"indonesia", "jakarta"},
+	"AU": {"australia", "sydney", "melbourne", "brisbane"},
+	"NZ": {"new zealand", "NZ", "auckland", "wellington"},
+}
+
+// usStates are the postal codes that end US locations such as "Austin, TX".
+var usStates = regexp.MustCompile(`,\s*(?:AL|AK|AZ|AR|CA|CO|CT|DE|DC|FL|GA|HI|ID|IL|IN|IA|KS|KY|LA|ME|MD|MA|MI|MN|MS|MO|MT|NE|NV|NH|NJ|NM|NY|NC|ND|OH|OK|OR|PA|RI|SC|SD|TN|TX|UT|VT|VA|WA|WV|WI|WY)\b`)
+
+// Names are matched case-insensitively, except abbreviations written in
+// capitals, which would otherwise match words such as "us" or "na".
+var namePattern, abbreviationPattern = compilePlaces()
+
+var placeCodes = map[string]string{}
+
+func compilePlaces() (*regexp.Regexp, *regexp.Regexp) {
+	var names, abbreviations []string
+
+	for code, aliases := range places {
+		for _, alias := range aliases {
+			if alias == strings.ToUpper(alias) {
+				abbreviations = append(abbreviations, alias)
+				placeCodes[alias] = code
+				continue
+			}
+			names = append(names, alias)
+			placeCodes[alias] = code
+		}
+	}
+
+	return alternation(names, "(?i)"), alternation(abbreviations, "")
+}
+
+// alternation matches the longest alias first, so "new york" beats "new".
+func alternation(aliases []string, flags string) *regexp.Regexp {
+	sortByLength(aliases)
+
+	quoted := make([]string, len(aliases))
+	for i, alias := range aliases {
+		quoted[i] = strings.ReplaceAll(regexp.QuoteMeta(alias), ` `, `[\s-]+`)
+	}
+
+	return regexp.MustCompile(flags + `(?:^|[^\pL\pN])(` + strings.Join(quoted, "|") + `)`)
+}
+
+func sortByLength(values []string) {
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+}
+
+// placeMatch is a place named in a text, with the offsets of the name.
+type placeMatch struct {
+	code       string
+	start, end int
+}
+
+// findPlaces returns the places named in text, in order.
+func findPlaces(text string) []placeMatch {
+	var found []placeMatch
+
+	for _, re := range []*regexp.Regexp{namePattern, abbreviationPattern} {
+		for _, m := range re.FindAllStringSubmatchIndex(text, -1) {
+			start, end := m[2], m[3]
+
+			if !wordEnd(text, end) {
+				continue
+			}
+
+			name := text[start:end]
+			code, ok := placeCodes[name]
+			if !ok {
+				code = placeCodes[strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(name, "-", " "))), " ")]
+			}
+			if len(code) == 0 {
+				continue
+			}
+
+			// "anywhere in the US" restricts rather than opens the role
+			if code == Worldwide && anywhereIn.MatchString(text[end:]) {
+				continue
+			}
+
+			found = append(found, placeMatch{code: code, start: start, end: end})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].start < found[j].start })
+
+	return found
+}
+
+var anywhereIn = regexp.MustCompile(`(?i)^\s+(?:in|within|across)\b`)
+
+// wordEnd reports whether a name ending at i is not the start of a longer word.
+func wordEnd(text string, i int) bool {
+	if i >= len(text) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,160 @@
+package location
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// zones maps time zone names to their offset from UTC in minutes. Ambiguous
+// abbreviations such as IST are left out.
+var zones = map[string]int{
+	"utc": 0, "gmt": 0, "wet": 0, "bst": 60,
+	"cet": 60, "cest": 120, "central european time": 60,
+	"eet": 120, "eest": 180, "eastern european time": 120,
+	"est": -300, "edt": -240, "eastern time": -300,
+	"cst": -360, "cdt": -300, "central time": -360,
+	"mst": -420, "mdt": -360, "mountain time": -420,
+	"pst": -480, "pdt": -420, "pacific time": -480,
+	"brt": -180, "jst": 540, "sgt": 480, "aest": 600, "aedt": 660,
+}
+
+const (
+	minOffset = -12 * 60
+	maxOffset = 14 * 60
+)
+
+var zonePattern = func() *regexp.Regexp {
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, strings.ReplaceAll(name, " ", `\s+`))
+	}
+	// longer names first so that "cest" is not read as "cet"
+	sortByLength(names)
+
+	return regexp.MustCompile(`(?i)\b(?P<zone>` + strings.Join(names, "|") + `)\b` +
+		`(?:\s?(?P<sign>[+\-−])\s?(?P<hours>\d{1,2})(?::?(?P<minutes>[0-5]\d))?\b)?` +
+		`(?:\s?(?:±|\+/-|\+/−|\+-|plus\s+or\s+minus|plus/minus)\s?(?P<spread>\d{1,2})(?:\s?(?:h|hrs?|hours?)\b)?)?`)
+}()
+
+// rangeGap is the text allowed between the two ends of a range such as
+// "UTC-5 to UTC+1".
+var rangeGap = regexp.MustCompile(`(?i)^\s*(?:-|–|—|to|and|through|till|until)\s*$`)
+
+// zoneContext marks a bare zone name as a restriction rather than, say, the
+// zone of timestamps in a log pipeline.
+var zoneContext = regexp.MustCompile(`(?i)time\s?-?zones?|\btz\b|overlap|hours|\bwithin\b|\bbased\b`)
+
+type offsetRange struct {
+	low, high int
+}
+
+type zoneMatch struct {
+	offsetRange
+	bare       bool
+	start, end int
+}
+
+// findTimezone returns the span of UTC offsets that text restricts the role
+// to, e.g. "UTC-3..UTC+3", or an empty string when it names none.
+func findTimezone(text string) string {
+	var matches []zoneMatch
+
+	names := zonePattern.SubexpNames()
+
+	for _, m := range zonePattern.FindAllStringSubmatchIndex(text, -1) {
+		groups := map[string]string{}
+		for i, name := range names {
+			if len(name) > 0 && m[2*i] >= 0 {
+				groups[name] = text[m[2*i]:m[2*i+1]]
+			}
+		}
+
+		offset := zones[strings.Join(strings.Fields(strings.ToLower(groups["zone"])), " ")]
+
+		if sign, ok := groups["sign"]; ok {
+			hours, _ := strconv.Atoi(groups["hours"])
+			minutes, _ := strconv.Atoi(groups["minutes"])
+			shift := hours*60 + minutes
+			if sign != "+" {
+				shift = -shift
+			}
+			offset += shift
+		}
+
+		spread := 0
+		if s, ok := groups["spread"]; ok {
+			spread, _ = strconv.Atoi(s)
+			spread *= 60
+		}
+
+		if offset < minOffset || offset > maxOffset {
+			continue
+		}
+
+		matches = append(matches, zoneMatch{
+			offsetRange: offsetRange{low: offset - spread, high: offset + spread},
+			bare:        len(groups["sign"]) == 0 && spread == 0,
+			start:       m[0],
+			end:         m[1],
+		})
+	}
+
+	var spans []offsetRange
+
+	for i := 0; i < len(matches); i++ {
+		current := matches[i]
+
+		if i+1 < len(matches) && rangeGap.MatchString(text[current.end:matches[i+1].start]) {
+			next := matches[i+1]
+			spans = append(spans, offsetRange{low: min(current.low, next.low), high: max(current.high, next.high)})
+			i++
+			continue
+		}
+
+		if current.bare && !zoneContext.MatchString(around(text, current.start, current.end, 40)) {
+			continue
+		}
+
+		spans = append(spans, current.offsetRange)
+	}
+
+	if len(spans) == 0 {
+		return ""
+	}
+
+	span := spans[0]
+	for _, s := range spans[1:] {
+		span.low = min(span.low, s.low)
+		span.high = max(span.high, s.high)
+	}
+
+	span.low = max(span.low, minOffset)
+	span.high = min(span.high, maxOffset)
+
+	if span.low == span.high {
+		return formatOffset(span.low)
+	}
+
+	return formatOffset(span.low) + ".." + formatOffset(span.high)
+}
+
+func formatOffset(minutes int) string {
+	sign := "+"
+	if minutes < 0 {
+		sign = "-"
+		minutes = -minutes
+	}
+
+	if minutes%60 == 0 {
+		return fmt.Sprintf("UTC%s%d", sign, minutes/60)
+	}
+
+	return fmt.Sprintf("UTC%s%d:%02d", sign, minutes/60, minutes%60)
+}
+
+// around returns up to n bytes of text on either side of [start, end).
+func around(text string, start, end, n int) string {
+	return text[max(0, start-n):min(len(text), end+n)]
+}
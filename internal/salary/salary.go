@@ -0,0 +1,241 @@
+// Package salary finds pay ranges in free text and normalizes them.
+package salary
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Periods use the schema.org unitText values that structured postings carry.
+const (
+	Hour  = "HOUR"
+	Day   = "DAY"
+	Week  = "WEEK"
+	Month = "MONTH"
+	Year  = "YEAR"
+)
+
+// Salary is a normalized pay range. Min or Max is zero when the text only
+// gives an upper ("up to") or lower ("from") bound.
+type Salary struct {
+	Min      float64
+	Max      float64
+	Currency string
+	Period   string
+}
+
+const (
+	currencyPattern = `US\$|CA\$|C\$|AU\$|A\$|\$|€|£|¥|₹|\b(?:USD|EUR|GBP|CHF|CAD|AUD|JPY|INR|SEK|NOK|DKK|PLN)\b`
+	numberPattern   = `\d{1,2}(?:,\d{2})+,\d{3}|\d{1,3}(?:[,.'’ \x{00a0}\x{202f}]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?`
+	thousandPattern = `\s?[kK]\b`
+	largePattern    = `\s?(?:[mM]{1,2}|[mM]n|[bB]n?|million|billion)\b`
+	rangePattern    = `\s?(?:-|–|—|~|to|and)\s?`
+)
+
+var amountPattern = regexp.MustCompile(
+	`(?i)(?P<c1>` + currencyPattern + `)?\s?(?P<n1>` + numberPattern + `)(?P<k1>` + thousandPattern + `)?(?P<l1>` + largePattern + `)?\s?(?P<c2>` + currencyPattern + `)?` +
+		`(?:` + rangePattern + `(?P<c3>` + currencyPattern + `)?\s?(?P<n2>` + numberPattern + `)(?P<k2>` + thousandPattern + `)?(?P<l2>` + largePattern + `)?\s?(?P<c4>` + currencyPattern + `)?)?`,
+)
+
+var currencies = map[string]string{
+	"$":   "USD",
+	"us$": "USD",
+	"ca$": "CAD",
+	"c$":  "CAD",
+	"au$": "AUD",
+	"a$":  "AUD",
+	"€":   "EUR",
+	"£":   "GBP",
+	"¥":   "JPY",
+	"₹":   "INR",
+}
+
+// periodAfter matches a period right after the amount, e.g. "/day",
+// "per year", "an hour", "p.a." or "annually".
+var periodAfter = regexp.MustCompile(`(?i)^[\s,]*(?:(?:/|per|an?|a\s+|each)\s*)?(hours?|hourly|hrs?|h|days?|daily|weeks?|weekly|wk|months?|monthly|mo|years?|yearly|yr|annum|annual(?:ly)?|p\.?\s?a\.?)\b\.?`)
+
+// periodBefore matches a period named just before the amount, e.g. "day rate:".
+var periodBefore = regexp.MustCompile(`(?i)\b(hourly|daily|day|weekly|monthly|annual|yearly|base)\s*(?:rate|salary|pay|compensation|wage)?\s*(?:of|is|:|from|between|up\s+to)?\s*$`)
+
+var upTo = regexp.MustCompile(`(?i)\b(?:up\s+to|max(?:imum)?|no\s+more\s+than)\s*:?\s*$`)
+var from = regexp.MustCompile(`(?i)\b(?:from|starting\s+(?:at|from)|min(?:imum)?|at\s+least)\s*:?\s*$`)
+
+var periods = map[string]string{
+	"hour": Hour, "hours": Hour, "hourly": Hour, "hr": Hour, "hrs": Hour, "h": Hour,
+	"day": Day, "days": Day, "daily": Day,
+	"week": Week, "weeks": Week, "weekly": Week, "wk": Week,
+	"month": Month, "months": Month, "monthly": Month, "mo": Month,
+	"year": Year, "years": Year, "yearly": Year, "yr": Year, "annum": Year,
+	"annual": Year, "annually": Year, "base": Year,
+}
+
+// Extract returns the first pay range in text that names a currency and
+// either a period or an amount of at least a thousand, so that prices and
+// funding rounds are not mistaken for pay. The period is inferred as YEAR
+// for amounts of ten thousand or more when the text does not name one.
+func Extract(text string) (Salary, bool) {
+	for _, m := range amountPattern.FindAllStringSubmatchIndex(text, -1) {
+		s, ok := parse(text, m)
+		if ok {
+			return s, true
+		}
+	}
+
+	return Salary{}, false
+}
+
+func parse(text string, m []int) (Salary, bool) {
+	group := func(name string) string {
+		i := amountPattern.SubexpIndex(name)
+		if m[2*i] < 0 {
+			return ""
+		}
+		return text[m[2*i]:m[2*i+1]]
+	}
+
+	if len(group("l1")) > 0 || len(group("l2")) > 0 {
+		return Salary{}, false
+	}
+
+	currency := ""
+	for _, name := range []string{"c1", "c2", "c3", "c4"} {
+		if c := group(name); len(c) > 0 {
+			currency = currencyCode(c)
+			break
+		}
+	}
+	if len(currency) == 0 {
+		return Salary{}, false
+	}
+
+	low, ok := number(group("n1"))
+	if !ok {
+		return Salary{}, false
+	}
+
+	high := low
+	isRange := len(group("n2")) > 0
+
+	if isRange {
+		if high, ok = number(group("n2")); !ok {
+			return Salary{}, false
+		}
+	}
+
+	k1, k2 := len(group("k1")) > 0, len(group("k2")) > 0
+
+	if k1 {
+		low *= 1000
+	}
+	if isRange && k2 {
+		high *= 1000
+		// "60-70k" puts the multiplier on the upper bound only
+		if !k1 && low < 1000 {
+			low *= 1000
+		}
+	} else if isRange && k1 && high < 1000 {
+		high *= 1000
+	} else if !isRange {
+		high = low
+	}
+
+	if low > high {
+		low, high = high, low
+	}
+
+	if low <= 0 {
+		return Salary{}, false
+	}
+
+	before := text[:m[0]]
+	after := text[m[1]:]
+
+	period := ""
+	if p := periodAfter.FindStringSubmatch(after); p != nil {
+		period = periodName(p[1])
+	} else if p := periodBefore.FindStringSubmatch(window(before)); p != nil {
+		period = periodName(p[1])
+	}
+
+	if len(period) == 0 && high < 1000 {
+		return Salary{}, false
+	}
+
+	if len(period) == 0 && low >= 10000 {
+		period = Year
+	}
+
+	s := Salary{
+		Min:      round(low),
+		Max:      round(high),
+		Currency: currency,
+		Period:   period,
+	}
+
+	if !isRange {
+		switch {
+		case upTo.MatchString(window(before)):
+			s.Min = 0
+		case from.MatchString(window(before)), strings.HasPrefix(after, "+"):
+			s.Max = 0
+		}
+	}
+
+	return s, true
+}
+
+// number parses an amount written with any common grouping: "120,000",
+// "70.000", "120'000", "65.000,00" or "1.5".
+func number(s string) (float64, bool) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "", "’", "").Replace(s)
+
+	last := strings.LastIndexAny(s, ".,")
+	if last >= 0 {
+		decimals := len(s) - last - 1
+		integer := strings.NewReplacer(".", "", ",", "").Replace(s[:last])
+
+		if decimals == 3 {
+			s = integer + s[last+1:]
+		} else {
+			s = integer + "." + s[last+1:]
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	return n, err == nil
+}
+
+func currencyCode(c string) string {
+	if code, ok := currencies[strings.ToLower(c)]; ok {
+		return code
+	}
+	return strings.ToUpper(c)
+}
+
+func periodName(p string) string {
+	p = strings.ToLower(strings.TrimSpace(p))
+
+	if strings.HasPrefix(p, "p") {
+		return Year
+	}
+
+	return periods[p]
+}
+
+// window is the end of the text before an amount, where qualifiers such as
+// "up to" or "day rate" appear.
+func window(before string) string {
+	const size = 40
+
+	if len(before) > size {
+		before = before[len(before)-size:]
+	}
+
+	return before
+}
+
+func round(n float64) float64 {
+	return math.Round(n*100) / 100
+}
@@ -0,0 +1,36 @@
+package admin
+
+import "net/http"
+
+type Option func(*Options)
+
+type Options struct {
+	Address  string
+	Handlers map[string]http.Handler
+}
+
+func WithAddress(addr string) Option {
+	return func(o *Options) {
+		o.Address = addr
+	}
+}
+
+// WithHandler mounts an extra handler, such as a metrics endpoint, at pattern.
+func WithHandler(pattern string, handler http.Handler) Option {
+	return func(o *Options) {
+		o.Handlers[pattern] = handler
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		Address:  ":8080",
+		Handlers: map[string]http.Handler{},
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
@@ -0,0 +1,169 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/w-h-a/scraper/internal/services/jobhunter"
+)
+
+// Hunter is the part of the job hunter the admin server exposes.
+type Hunter interface {
+	TriggerHunt() error
+	Status() jobhunter.Status
+}
+
+type Service struct {
+	options   Options
+	hunter    Hunter
+	server    *http.Server
+	listener  net.Listener
+	serveErr  chan error
+	isRunning bool
+	mtx       sync.RWMutex
+}
+
+func (s *Service) Run(stop chan struct{}) error {
+	if err := s.Start(); err != nil {
+		return fmt.Errorf("failed to start admin server: %w", err)
+	}
+
+	select {
+	case <-stop:
+		return s.Stop()
+	case err := <-s.serveErr:
+		return fmt.Errorf("admin server failed: %w", err)
+	}
+}
+
+func (s *Service) Start() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.isRunning {
+		return errors.New("admin server already started")
+	}
+
+	listener, err := net.Listen("tcp", s.options.Address)
+	if err != nil {
+		return err
+	}
+
+	s.listener = listener
+	s.serveErr = make(chan error, 1)
+	s.isRunning = true
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.serveErr <- err
+		}
+	}()
+
+	slog.InfoContext(context.Background(), "admin server listening", "address", listener.Addr().String())
+
+	return nil
+}
+
+// Addr returns the address the server is listening on once started.
+func (s *Service) Addr() string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if s.listener == nil {
+		return ""
+	}
+
+	return s.listener.Addr().String()
+}
+
+func (s *Service) Stop() error {
+	s.mtx.Lock()
+
+	if !s.isRunning {
+		s.mtx.Unlock()
+		return errors.New("admin server not running")
+	}
+
+	s.isRunning = false
+
+	s.mtx.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Service) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Service) handleReadyz(w http.ResponseWriter, _ *http.Request) {
+	if !s.hunter.Status().Ready {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "not ready"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func (s *Service) handleHunt(w http.ResponseWriter, r *http.Request) {
+	if err := s.hunter.TriggerHunt(); err != nil {
+		if errors.Is(err, jobhunter.ErrHuntInProgress) {
+			writeJSON(w, http.StatusConflict, map[string]string{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, jobhunter.ErrNotRunning) {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to trigger job hunt", "error", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "started"})
+}
+
+func (s *Service) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.hunter.Status())
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func New(hunter Hunter, opts ...Option) *Service {
+	options := NewOptions(opts...)
+
+	s := &Service{
+		options: options,
+		hunter:  hunter,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealthz)
+	mux.HandleFunc("GET /readyz", s.handleReadyz)
+	mux.HandleFunc("POST /hunt", s.handleHunt)
+	mux.HandleFunc("GET /status", s.handleStatus)
+
+	for pattern, handler := range options.Handlers {
+		mux.Handle(pattern, handler)
+	}
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return s
+}
@@ -0,0 +1,162 @@
+package jobhunter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/w-h-a/scraper/internal/clients/cache"
+)
+
+var ErrCircuitOpen = errors.New("feed circuit open")
+
+type circuitState string
+
+const breakerCacheKey = "circuit-breaker"
+
+const (
+	circuitClosed   circuitState = "closed"
+	circuitOpen     circuitState = "open"
+	circuitHalfOpen circuitState = "half-open"
+)
+
+// circuitBreaker stops scraping a feed after threshold consecutive failed
+// cycles. Once cooldown has passed the next cycle probes the feed again: a
+// success closes the circuit, a failure keeps it open for another cooldown.
+// With a cache the circuits are restored and persisted around every cycle,
+// so they survive runs that exit after one cycle.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	feeds     map[string]*feedCircuit
+	cache     cache.Cache
+	mtx       sync.Mutex
+}
+
+type feedCircuit struct {
+	Failures int       `json:"failures"`
+	OpenedAt time.Time `json:"opened_at,omitempty"`
+}
+
+func (b *circuitBreaker) allow(name string, now time.Time) circuitState {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	c, ok := b.feeds[name]
+	if !ok || b.threshold <= 0 || c.Failures < b.threshold {
+		return circuitClosed
+	}
+
+	if now.Sub(c.OpenedAt) >= b.cooldown {
+		return circuitHalfOpen
+	}
+
+	return circuitOpen
+}
+
+// record notes the result of scraping a feed and reports whether that result
+// opened, or reopened, its circuit.
+func (b *circuitBreaker) record(name string, err error, now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if err == nil {
+		delete(b.feeds, name)
+		return false
+	}
+
+	c, ok := b.feeds[name]
+	if !ok {
+		c = &feedCircuit{}
+		b.feeds[name] = c
+	}
+
+	c.Failures++
+
+	if b.threshold <= 0 || c.Failures < b.threshold {
+		return false
+	}
+
+	c.OpenedAt = now
+
+	return true
+}
+
+func (b *circuitBreaker) open() []string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	var names []string
+
+	for name, c := range b.feeds {
+		if b.threshold > 0 && c.Failures >= b.threshold {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// restore replaces the circuits with the ones last persisted. Without a
+// cache, or before anything was persisted, the circuits are left as they are.
+func (b *circuitBreaker) restore(ctx context.Context) error {
+	if b.cache == nil {
+		return nil
+	}
+
+	data, ok, err := b.cache.Get(ctx, breakerCacheKey)
+	if err != nil {
+		return fmt.Errorf("failed to read circuits: %w", err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	feeds := map[string]*feedCircuit{}
+	if err := json.Unmarshal(data, &feeds); err != nil {
+		return fmt.Errorf("failed to decode circuits: %w", err)
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.feeds = feeds
+
+	return nil
+}
+
+func (b *circuitBreaker) persist(ctx context.Context) error {
+	if b.cache == nil {
+		return nil
+	}
+
+	b.mtx.Lock()
+	data, err := json.Marshal(b.feeds)
+	b.mtx.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to encode circuits: %w", err)
+	}
+
+	if err := b.cache.Set(ctx, breakerCacheKey, data); err != nil {
+		return fmt.Errorf("failed to store circuits: %w", err)
+	}
+
+	return nil
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration, c cache.Cache) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		feeds:     map[string]*feedCircuit{},
+		cache:     c,
+	}
+}
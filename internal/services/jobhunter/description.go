@@ -0,0 +1,33 @@
+package jobhunter
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/w-h-a/scraper/internal/htmltext"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// plainText replaces each description with its text, cut to the configured limit.
+func (s *Service) plainText(ctx context.Context, jobs []JobPost) {
+	ctx, span := s.tracer.Start(ctx, "plainTextDescriptions")
+	defer span.End()
+
+	truncated := 0
+
+	for i := range jobs {
+		text, cut := htmltext.Truncate(htmltext.Convert(jobs[i].RawDescription), s.options.DescriptionLimit)
+		if cut {
+			truncated++
+			slog.InfoContext(ctx, "description truncated",
+				"source", jobs[i].Source,
+				"link", jobs[i].Link,
+				"limit", s.options.DescriptionLimit,
+			)
+		}
+
+		jobs[i].RawDescription = text
+	}
+
+	span.SetAttributes(attribute.Int("jobs.descriptions_truncated", truncated))
+}
@@ -0,0 +1,231 @@
+package jobhunter
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type DedupMode string
+
+const (
+	// DedupMerge writes one row per posting, listing every source and the
+	// other links in the also_listed_at column.
+	DedupMerge DedupMode = "merge"
+	// DedupFlag writes every row and marks later copies in their status.
+	DedupFlag DedupMode = "flag"
+)
+
+var DedupModes = map[string]DedupMode{
+	"merge": DedupMerge,
+	"flag":  DedupFlag,
+}
+
+const (
+	shingleSize   = 3
+	minHashLength = 64
+)
+
+// companySuffixes are dropped from company names before fingerprinting.
+var companySuffixes = map[string]bool{
+	"inc": true, "llc": true, "ltd": true, "limited": true, "gmbh": true,
+	"corp": true, "corporation": true, "co": true, "ag": true, "sa": true,
+	"bv": true, "plc": true, "srl": true,
+}
+
+// posting is one distinct role among the new jobs of a cycle.
+type posting struct {
+	index     int
+	sources   map[string]bool
+	signature []uint64
+}
+
+// dedupe finds jobs from different sources that are the same role: equal
+// fingerprints on company, title and location and, when both descriptions
+// are long enough to compare, similar descriptions. Without descriptions a
+// match also needs a known company, since a bare title is too common.
+// Only the jobs of this cycle are compared.
+func (s *Service) dedupe(ctx context.Context, jobs []JobPost, tally *sourceTally) []JobPost {
+	ctx, span := s.tracer.Start(ctx, "dedupeJobs")
+	defer span.End()
+
+	postings := map[string][]*posting{}
+	dropped := map[int]bool{}
+	matches := 0
+
+	for i := range jobs {
+		job := &jobs[i]
+		key := fingerprint(*job)
+		signature := minHash(job.RawDescription)
+
+		var original *posting
+
+		for _, p := range postings[key] {
+			if p.sources[job.Source] {
+				continue
+			}
+			if s.similar(jobs[p.index], p.signature, *job, signature) {
+				original = p
+				break
+			}
+		}
+
+		if original == nil {
+			postings[key] = append(postings[key], &posting{
+				index:     i,
+				sources:   map[string]bool{job.Source: true},
+				signature: signature,
+			})
+			continue
+		}
+
+		matches++
+		first := &jobs[original.index]
+
+		slog.InfoContext(ctx, "possible duplicate job",
+			"source", job.Source,
+			"title", job.JobTitle,
+			"link", job.Link,
+			"duplicate_of", first.Link,
+		)
+
+		original.sources[job.Source] = true
+
+		switch s.options.DedupMode {
+		case DedupMerge:
+			// links are canonical since ingest, so the merged link is too
+			first.AlsoSources = append(first.AlsoSources, job.Source)
+			first.AlsoListedAt = append(first.AlsoListedAt, job.Link)
+			dropped[i] = true
+			tally.duplicated(job.Source)
+		case DedupFlag:
+			job.Status = "Possible duplicate of " + first.Link
+		}
+	}
+
+	span.SetAttributes(attribute.Int("jobs.fuzzy_duplicates", matches))
+
+	if len(dropped) == 0 {
+		return jobs
+	}
+
+	kept := make([]JobPost, 0, len(jobs)-len(dropped))
+	for i, job := range jobs {
+		if !dropped[i] {
+			kept = append(kept, job)
+		}
+	}
+
+	return kept
+}
+
+func (s *Service) similar(a JobPost, aSig []uint64, b JobPost, bSig []uint64) bool {
+	if aSig == nil || bSig == nil {
+		return len(normalize(a.Company)) > 0
+	}
+
+	return similarity(aSig, bSig) >= s.options.DedupThreshold
+}
+
+func fingerprint(job JobPost) string {
+	return strings.Join([]string{
+		normalizeCompany(job.Company),
+		normalize(job.JobTitle),
+		normalize(job.Location),
+	}, "|")
+}
+
+func normalizeCompany(company string) string {
+	words := strings.Fields(normalize(company))
+
+	for len(words) > 1 && companySuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// normalize lower-cases text and reduces everything but letters and digits
+// to single spaces.
+func normalize(text string) string {
+	return strings.Join(words(text), " ")
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// minHash returns the MinHash signature of the text's word shingles, or nil
+// when the text is too short to compare.
+func minHash(text string) []uint64 {
+	tokens := words(text)
+	if len(tokens) < shingleSize {
+		return nil
+	}
+
+	signature := make([]uint64, minHashLength)
+	for i := range signature {
+		signature[i] = ^uint64(0)
+	}
+
+	for i := 0; i+shingleSize <= len(tokens); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(tokens[i:i+shingleSize], " ")))
+		shingle := h.Sum64()
+
+		for j := range signature {
+			signature[j] = min(signature[j], mix(shingle^seeds[j]))
+		}
+	}
+
+	return signature
+}
+
+// similarity estimates the Jaccard similarity of the shingle sets.
+func similarity(a []uint64, b []uint64) float64 {
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// seeds give each signature slot its own hash function.
+var seeds = func() []uint64 {
+	seeds := make([]uint64, minHashLength)
+	state := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		state = mix(state + uint64(i))
+		seeds[i] = state
+	}
+	return seeds
+}()
+
+// mix is the splitmix64 finalizer.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// sourceCell names the source of a job followed by those of any duplicates
+// merged into it.
+func sourceCell(job JobPost) string {
+	return strings.Join(append([]string{job.Source}, job.AlsoSources...), ", ")
+}
+
+// alsoListedCell joins the other links of a merged job into one cell.
+func alsoListedCell(links []string) string {
+	return strings.Join(links, "\n")
+}
@@ -0,0 +1,100 @@
+package jobhunter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/w-h-a/scraper/internal/clients/enricher"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// detail pages are fetched a few at a time to stay polite to job boards
+const enrichConcurrency = 4
+
+func (s *Service) enrich(ctx context.Context, jobs []JobPost) {
+	ctx, span := s.tracer.Start(ctx, "enrichJobs")
+	defer span.End()
+
+	var wg sync.WaitGroup
+	var enriched, missing, failed atomic.Int64
+
+	sem := make(chan struct{}, enrichConcurrency)
+
+	for i := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(job *JobPost) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			fetchCtx, cancel := context.WithTimeout(ctx, s.options.EnrichTimeout)
+			defer cancel()
+
+			posting, err := s.options.Enricher.Enrich(fetchCtx, job.Link)
+			if errors.Is(err, enricher.ErrNoPosting) {
+				missing.Add(1)
+				return
+			}
+			if err != nil {
+				failed.Add(1)
+				slog.WarnContext(ctx, "failed to enrich job", "link", job.Link, "error", err)
+				return
+			}
+
+			applyPosting(job, posting)
+			enriched.Add(1)
+		}(&jobs[i])
+	}
+
+	wg.Wait()
+
+	span.SetAttributes(
+		attribute.Int64("jobs.enriched", enriched.Load()),
+		attribute.Int64("jobs.enrich_missing", missing.Load()),
+		attribute.Int64("jobs.enrich_failed", failed.Load()),
+	)
+}
+
+// applyPosting copies structured fields onto the job. Values the source feed
+// already provided are only replaced where the posting is more complete.
+func applyPosting(job *JobPost, posting *enricher.Posting) {
+	if len(posting.HiringOrganization) > 0 {
+		job.Company = posting.HiringOrganization
+	}
+
+	if len(posting.EmploymentType) > 0 && len(job.EmploymentType) == 0 {
+		job.EmploymentType = posting.EmploymentType
+	}
+
+	if posting.SalaryMin > 0 || posting.SalaryMax > 0 {
+		job.SalaryMin = posting.SalaryMin
+		job.SalaryMax = posting.SalaryMax
+		job.SalaryCurrency = posting.SalaryCurrency
+		job.SalaryPeriod = posting.SalaryPeriod
+	}
+
+	if strings.EqualFold(posting.JobLocationType, "TELECOMMUTE") {
+		job.Workplace = "remote"
+	}
+
+	if len(posting.JobLocation) > 0 && len(job.Location) == 0 {
+		job.Location = posting.JobLocation
+	}
+
+	if len(posting.ApplicantLocationRequirements) > 0 {
+		job.Regions = posting.ApplicantLocationRequirements
+	}
+
+	if len(posting.ValidThrough) > 0 {
+		job.ValidThrough = posting.ValidThrough
+	}
+
+	if len(posting.Description) > len(job.RawDescription) {
+		job.RawDescription = posting.Description
+	}
+}
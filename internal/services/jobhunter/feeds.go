@@ -1,7 +1,226 @@
 package jobhunter
 
-var (
-	RSSFeeds = map[string]string{
-		"Golang Projects": "https://www.golangprojects.com/rss.xml",
-	}
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/w-h-a/scraper/internal/clients/scraper"
+	"gopkg.in/yaml.v3"
 )
+
+type Feed struct {
+	Name string
+	URL  string
+	// Scraper selects the scraper for this feed. Empty means the service default.
+	Scraper scraper.ScraperType
+	Enabled bool
+	Tags    []string
+}
+
+type feedFile struct {
+	Feeds []feedEntry `yaml:"feeds"`
+}
+
+type feedEntry struct {
+	Name    string `yaml:"name"`
+	URL     string `yaml:"url"`
+	Scraper string `yaml:"scraper"`
+	// Tokens lists board tokens for scrapers that take them in place of a
+	// URL. Each token becomes a feed of its own.
+	Tokens  []string `yaml:"tokens"`
+	Enabled *bool    `yaml:"enabled"`
+	Tags    []string `yaml:"tags"`
+}
+
+// tokenScrapers accept a bare board token, such as "acme", as a feed URL.
+var tokenScrapers = map[scraper.ScraperType]bool{
+	scraper.Greenhouse: true,
+	scraper.Lever:      true,
+}
+
+var boardToken = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// DefaultFeeds is used when no feed file is configured.
+func DefaultFeeds() []Feed {
+	return []Feed{
+		{
+			Name:    "Golang Projects",
+			URL:     "https://www.golangprojects.com/rss.xml",
+			Enabled: true,
+			Tags:    []string{"go"},
+		},
+	}
+}
+
+// LoadFeeds reads feed definitions from a YAML or JSON file and validates them.
+func LoadFeeds(path string) ([]Feed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feeds file at %s: %w", path, err)
+	}
+
+	return ParseFeeds(data)
+}
+
+// ParseFeeds decodes feed definitions and validates them.
+func ParseFeeds(data []byte) ([]Feed, error) {
+	var file feedFile
+
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse feeds: %w", err)
+	}
+
+	var errs []error
+
+	feeds := make([]Feed, 0, len(file.Feeds))
+
+	for i, entry := range file.Feeds {
+		enabled := true
+		if entry.Enabled != nil {
+			enabled = *entry.Enabled
+		}
+
+		feed := Feed{
+			Name:    strings.TrimSpace(entry.Name),
+			URL:     strings.TrimSpace(entry.URL),
+			Scraper: scraper.ScraperType(strings.TrimSpace(entry.Scraper)),
+			Enabled: enabled,
+			Tags:    entry.Tags,
+		}
+
+		if len(entry.Tokens) == 0 {
+			feeds = append(feeds, feed)
+			continue
+		}
+
+		if len(feed.URL) > 0 {
+			errs = append(errs, fmt.Errorf("feed %d: url and tokens are exclusive", i))
+			continue
+		}
+
+		if !tokenScrapers[feed.Scraper] {
+			errs = append(errs, fmt.Errorf("feed %d: tokens need a greenhouse or lever scraper", i))
+			continue
+		}
+
+		for _, token := range entry.Tokens {
+			tokenFeed := feed
+			tokenFeed.URL = strings.TrimSpace(token)
+			tokenFeed.Name = tokenFeed.URL
+			if len(feed.Name) > 0 {
+				tokenFeed.Name = feed.Name + "/" + tokenFeed.URL
+			}
+			feeds = append(feeds, tokenFeed)
+		}
+	}
+
+	if err := errors.Join(append(errs, ValidateFeeds(feeds))...); err != nil {
+		return nil, err
+	}
+
+	return feeds, nil
+}
+
+// ValidateFeeds checks that every feed is well formed, that names are unique
+// and that at least one feed is enabled.
+func ValidateFeeds(feeds []Feed) error {
+	var errs []error
+
+	names := map[string]bool{}
+	enabled := 0
+
+	for i, feed := range feeds {
+		if len(feed.Name) == 0 {
+			errs = append(errs, fmt.Errorf("feed %d: name is required", i))
+		} else if names[feed.Name] {
+			errs = append(errs, fmt.Errorf("feed %q: duplicate name", feed.Name))
+		}
+
+		names[feed.Name] = true
+
+		if err := validateFeedURL(feed); err != nil {
+			errs = append(errs, fmt.Errorf("feed %q: %w", feed.Name, err))
+		}
+
+		if len(feed.Scraper) > 0 {
+			if !scraper.Registered(feed.Scraper) {
+				errs = append(errs, fmt.Errorf("feed %q: unsupported scraper %q", feed.Name, feed.Scraper))
+			}
+		}
+
+		if feed.Enabled {
+			enabled++
+		}
+	}
+
+	if enabled == 0 {
+		errs = append(errs, errors.New("no enabled feeds"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateFeedURL accepts an http(s) URL with a host or, for scrapers that
+// take them, a bare board token.
+func validateFeedURL(feed Feed) error {
+	raw := feed.URL
+
+	if len(raw) == 0 {
+		return errors.New("url is required")
+	}
+
+	if tokenScrapers[feed.Scraper] && !strings.Contains(raw, "://") {
+		if !boardToken.MatchString(raw) {
+			return fmt.Errorf("invalid board token %q", raw)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+
+	if len(u.Host) == 0 {
+		return errors.New("url has no host")
+	}
+
+	return nil
+}
+
+// ScraperTypes returns the distinct scraper types selected by the enabled feeds.
+func ScraperTypes(feeds []Feed) []scraper.ScraperType {
+	var types []scraper.ScraperType
+
+	seen := map[scraper.ScraperType]bool{}
+
+	for _, feed := range enabledFeeds(feeds) {
+		if len(feed.Scraper) == 0 || seen[feed.Scraper] {
+			continue
+		}
+		seen[feed.Scraper] = true
+		types = append(types, feed.Scraper)
+	}
+
+	return types
+}
+
+func enabledFeeds(feeds []Feed) []Feed {
+	var enabled []Feed
+
+	for _, feed := range feeds {
+		if feed.Enabled {
+			enabled = append(enabled, feed)
+		}
+	}
+
+	return enabled
+}
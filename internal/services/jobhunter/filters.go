@@ -0,0 +1,238 @@
+package jobhunter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldSource      = "source"
+	FieldLocation    = "location"
+	FieldWorkplace   = "workplace"
+	FieldRegions     = "regions"
+	FieldTimezone    = "timezone"
+	FieldTags        = "tags"
+	FieldSeniority   = "seniority"
+)
+
+var filterFields = map[string]func(JobPost) string{
+	FieldTitle:       func(j JobPost) string { return j.JobTitle },
+	FieldDescription: func(j JobPost) string { return j.RawDescription },
+	FieldSource:      func(j JobPost) string { return j.Source },
+	FieldLocation:    func(j JobPost) string { return j.Location },
+	FieldWorkplace:   func(j JobPost) string { return j.Workplace },
+	FieldRegions:     func(j JobPost) string { return j.Regions },
+	FieldTimezone:    func(j JobPost) string { return j.Timezone },
+	FieldTags:        func(j JobPost) string { return strings.Join(j.Tags, ", ") },
+	FieldSeniority:   func(j JobPost) string { return j.Seniority },
+}
+
+// earlyFields are settled when a job is scraped. Rules on them alone run
+// before enrichment, so the jobs they drop never cost a detail page fetch.
+var earlyFields = map[string]bool{
+	FieldTitle:  true,
+	FieldSource: true,
+}
+
+// FilterRules decide which new jobs are written. A job is kept when it matches
+// every include rule and none of the exclude rules.
+type FilterRules struct {
+	Include []FilterRule
+	Exclude []FilterRule
+}
+
+// FilterRule matches when any of its keywords or patterns is found in any of
+// its fields. Keywords match whole words, case-insensitively.
+type FilterRule struct {
+	Name     string
+	Fields   []string
+	patterns []*regexp.Regexp
+}
+
+type filterFile struct {
+	Include []filterEntry `yaml:"include"`
+	Exclude []filterEntry `yaml:"exclude"`
+}
+
+type filterEntry struct {
+	Name     string   `yaml:"name"`
+	Fields   []string `yaml:"fields"`
+	Keywords []string `yaml:"keywords"`
+	Regex    []string `yaml:"regex"`
+}
+
+// LoadFilterRules reads filter rules from a YAML or JSON file and compiles them.
+func LoadFilterRules(path string) (*FilterRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read filters file at %s: %w", path, err)
+	}
+
+	return ParseFilterRules(data)
+}
+
+// ParseFilterRules decodes filter rules and compiles their keywords and patterns.
+func ParseFilterRules(data []byte) (*FilterRules, error) {
+	var file filterFile
+
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse filters: %w", err)
+	}
+
+	var errs []error
+
+	include, err := compileRules("include", file.Include)
+	errs = append(errs, err)
+
+	exclude, err := compileRules("exclude", file.Exclude)
+	errs = append(errs, err)
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &FilterRules{
+		Include: include,
+		Exclude: exclude,
+	}, nil
+}
+
+func compileRules(kind string, entries []filterEntry) ([]FilterRule, error) {
+	var errs []error
+
+	rules := make([]FilterRule, 0, len(entries))
+
+	for i, entry := range entries {
+		name := strings.TrimSpace(entry.Name)
+		if len(name) == 0 {
+			name = fmt.Sprintf("%s[%d]", kind, i)
+		}
+
+		fields := entry.Fields
+		if len(fields) == 0 {
+			fields = []string{FieldTitle, FieldDescription, FieldSource}
+		}
+
+		for _, field := range fields {
+			if _, ok := filterFields[field]; !ok {
+				errs = append(errs, fmt.Errorf("rule %q: unsupported field %q", name, field))
+			}
+		}
+
+		if len(entry.Keywords) == 0 && len(entry.Regex) == 0 {
+			errs = append(errs, fmt.Errorf("rule %q: needs keywords or regex", name))
+		}
+
+		rule := FilterRule{
+			Name:   name,
+			Fields: fields,
+		}
+
+		for _, keyword := range entry.Keywords {
+			keyword = strings.TrimSpace(keyword)
+			if len(keyword) == 0 {
+				continue
+			}
+			rule.patterns = append(rule.patterns, regexp.MustCompile(`(?i)(?:^|[^\pL\pN])`+regexp.QuoteMeta(keyword)+`(?:$|[^\pL\pN])`))
+		}
+
+		for _, expr := range entry.Regex {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("rule %q: invalid regex %q: %w", name, expr, err))
+				continue
+			}
+			rule.patterns = append(rule.patterns, re)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, errors.Join(errs...)
+}
+
+func (r FilterRule) matches(job JobPost) bool {
+	for _, field := range r.Fields {
+		value := filterFields[field](job)
+
+		for _, re := range r.patterns {
+			if re.MatchString(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// early reports whether the rule only reads fields settled at scrape time.
+func (r FilterRule) early() bool {
+	for _, field := range r.Fields {
+		if !earlyFields[field] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// evaluate reports whether the job is kept by the early or the late rules
+// and, if not, the rule that rejected it.
+func (f *FilterRules) evaluate(job JobPost, early bool) (bool, string) {
+	for _, rule := range f.Exclude {
+		if rule.early() == early && rule.matches(job) {
+			return false, "exclude:" + rule.Name
+		}
+	}
+
+	for _, rule := range f.Include {
+		if rule.early() == early && !rule.matches(job) {
+			return false, "include:" + rule.Name
+		}
+	}
+
+	return true, ""
+}
+
+// filter applies the early rules, on title and source, or the late rules,
+// which read the description and the fields derived from it.
+func (s *Service) filter(ctx context.Context, jobs []JobPost, early bool) []JobPost {
+	ctx, span := s.tracer.Start(ctx, "filterJobs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Bool("filter.early", early))
+
+	kept := make([]JobPost, 0, len(jobs))
+	filteredOut := 0
+
+	for _, job := range jobs {
+		ok, rule := s.options.Filters.evaluate(job, early)
+		if ok {
+			kept = append(kept, job)
+			continue
+		}
+
+		filteredOut++
+
+		slog.InfoContext(ctx, "job filtered out",
+			"source", job.Source,
+			"title", job.JobTitle,
+			"link", job.Link,
+			"rule", rule,
+		)
+	}
+
+	span.SetAttributes(attribute.Int("jobs.filtered_out", filteredOut))
+
+	return kept
+}
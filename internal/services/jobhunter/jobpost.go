@@ -7,4 +7,49 @@ type JobPost struct {
 	Link           string
 	RawDescription string
 	Status         string
+	Team           string
+	Location       string
+	EmploymentType string
+	Company        string
+	SalaryMin      float64
+	SalaryMax      float64
+	SalaryCurrency string
+	SalaryPeriod   string
+	Workplace      string
+	ValidThrough   string
+	// AlsoListedAt holds the links of duplicates merged into this job.
+	AlsoListedAt []string
+	// AlsoSources holds the sources of duplicates merged into this job.
+	// Source stays the feed the job came from, which counts it.
+	AlsoSources []string
+	// Regions lists the countries and regions the job is open to. Sources
+	// may seed it with the names they give, which classification normalizes.
+	Regions   string
+	Timezone  string
+	Tags      []string
+	Seniority string
+}
+
+// Columns names the cells of each row handed to WriteBatch, in order.
+var Columns = []string{
+	"date_posted",
+	"source",
+	"job_title",
+	"link",
+	"raw_description",
+	"status",
+	"team",
+	"location",
+	"employment_type",
+	"company",
+	"salary_min",
+	"salary_max",
+	"salary_currency",
+	"salary_period",
+	"workplace",
+	"valid_through",
+	"also_listed_at",
+	"regions",
+	"timezone",
+	"tags",
 }
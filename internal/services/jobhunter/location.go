@@ -0,0 +1,46 @@
+package jobhunter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/w-h-a/scraper/internal/location"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// classifyLocations works out the workplace, regions and time zone of each
+// job from its structured fields, title and description.
+func (s *Service) classifyLocations(ctx context.Context, jobs []JobPost) {
+	_, span := s.tracer.Start(ctx, "classifyLocations")
+	defer span.End()
+
+	remote, restricted := 0, 0
+
+	for i := range jobs {
+		job := &jobs[i]
+
+		result := location.Classify(location.Fields{
+			Title:       job.JobTitle,
+			Description: job.RawDescription,
+			Location:    job.Location,
+			Workplace:   job.Workplace,
+			Regions:     job.Regions,
+		})
+
+		job.Workplace = result.Workplace
+		job.Regions = strings.Join(result.Regions, ", ")
+		job.Timezone = result.Timezone
+
+		if job.Workplace == location.Remote {
+			remote++
+		}
+		if len(job.Regions) > 0 || len(job.Timezone) > 0 {
+			restricted++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("jobs.remote", remote),
+		attribute.Int("jobs.location_restricted", restricted),
+	)
+}
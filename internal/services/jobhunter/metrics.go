@@ -0,0 +1,121 @@
+package jobhunter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type metrics struct {
+	jobsFound     metric.Int64Counter
+	feedDuration  metric.Float64Histogram
+	feedErrors    metric.Int64Counter
+	writeDuration metric.Float64Histogram
+	cycleDuration metric.Float64Histogram
+}
+
+func (m *metrics) recordFeed(ctx context.Context, source string, elapsed time.Duration, found int, err error) {
+	attrs := metric.WithAttributes(attribute.String("feed.source", source))
+
+	m.feedDuration.Record(ctx, elapsed.Seconds(), attrs)
+
+	if err != nil {
+		m.recordFeedError(ctx, source, err)
+		return
+	}
+
+	m.jobsFound.Add(ctx, int64(found), attrs)
+}
+
+func (m *metrics) recordFeedError(ctx context.Context, source string, err error) {
+	m.feedErrors.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("feed.source", source),
+		attribute.String("error.type", errorType(err)),
+	))
+}
+
+func (m *metrics) recordWrite(ctx context.Context, elapsed time.Duration, err error) {
+	m.writeDuration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(
+		attribute.Bool("error", err != nil),
+	))
+}
+
+func (m *metrics) recordCycle(ctx context.Context, cycle *CycleStatus) {
+	m.cycleDuration.Record(ctx, cycle.FinishedAt.Sub(cycle.StartedAt).Seconds(), metric.WithAttributes(
+		attribute.String("outcome", string(cycle.Outcome)),
+	))
+}
+
+// errorType buckets feed errors into a small, fixed set of labels.
+func errorType(err error) string {
+	var httpErr gofeed.HTTPError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &httpErr):
+		return fmt.Sprintf("http_%dxx", httpErr.StatusCode/100)
+	case errors.As(err, &netErr):
+		return "network"
+	case errors.Is(err, gofeed.ErrFeedTypeNotDetected):
+		return "parse"
+	default:
+		return "other"
+	}
+}
+
+func newMetrics() *metrics {
+	meter := otel.Meter("job-hunter")
+
+	// Instrument errors only arise from invalid names, and the returned
+	// instruments stay usable either way.
+	jobsFound, _ := meter.Int64Counter(
+		"jobhunter.jobs.found",
+		metric.WithDescription("New jobs found per source"),
+		metric.WithUnit("{job}"),
+	)
+
+	feedDuration, _ := meter.Float64Histogram(
+		"jobhunter.feed.fetch.duration",
+		metric.WithDescription("Time taken to fetch and parse a feed"),
+		metric.WithUnit("s"),
+	)
+
+	feedErrors, _ := meter.Int64Counter(
+		"jobhunter.feed.errors",
+		metric.WithDescription("Feed fetch failures by source and error type"),
+		metric.WithUnit("{error}"),
+	)
+
+	writeDuration, _ := meter.Float64Histogram(
+		"jobhunter.write.duration",
+		metric.WithDescription("Time taken to write a batch to the readwriter"),
+		metric.WithUnit("s"),
+	)
+
+	cycleDuration, _ := meter.Float64Histogram(
+		"jobhunter.cycle.duration",
+		metric.WithDescription("Time taken by a full job hunt cycle"),
+		metric.WithUnit("s"),
+	)
+
+	return &metrics{
+		jobsFound:     jobsFound,
+		feedDuration:  feedDuration,
+		feedErrors:    feedErrors,
+		writeDuration: writeDuration,
+		cycleDuration: cycleDuration,
+	}
+}
@@ -0,0 +1,34 @@
+package jobhunter
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/w-h-a/scraper/internal/clients/notifier"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// notify announces newly written jobs. Failures are logged and recorded but
+// never fail the hunt, since the jobs are already stored.
+func (s *Service) notify(ctx context.Context, jobs []JobPost) {
+	ctx, span := s.tracer.Start(ctx, "notifyJobs")
+	defer span.End()
+
+	notifications := make([]notifier.Notification, len(jobs))
+
+	for i, job := range jobs {
+		notifications[i] = notifier.Notification{
+			Title:  job.JobTitle,
+			Source: sourceCell(job),
+			Link:   job.Link,
+			Tags:   job.labels(),
+		}
+	}
+
+	span.SetAttributes(attribute.Int("notifications.count", len(notifications)))
+
+	if err := s.options.Notifier.Notify(ctx, notifications); err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "failed to send notification", "jobs", len(jobs), "error", err)
+	}
+}
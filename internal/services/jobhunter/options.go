@@ -0,0 +1,170 @@
+package jobhunter
+
+import (
+	"time"
+
+	"github.com/w-h-a/scraper/internal/canonical"
+	"github.com/w-h-a/scraper/internal/clients/cache"
+	"github.com/w-h-a/scraper/internal/clients/enricher"
+	"github.com/w-h-a/scraper/internal/clients/notifier"
+	"github.com/w-h-a/scraper/internal/clients/outbox"
+	"github.com/w-h-a/scraper/internal/clients/scraper"
+	"github.com/w-h-a/scraper/internal/tagging"
+)
+
+type Option func(*Options)
+
+type Options struct {
+	Feeds    []Feed
+	Scrapers map[scraper.ScraperType]scraper.Scraper
+	Enricher enricher.Enricher
+	// EnrichTimeout bounds each detail page fetch, retries included, so that
+	// pages which hang cannot hold every slot until the cycle times out.
+	EnrichTimeout time.Duration
+	Filters       *FilterRules
+	Notifier      notifier.Notifier
+	Outbox        outbox.Outbox
+	Schedule      *Schedule
+	// Canonicalizer rewrites links before they are deduped and written.
+	Canonicalizer *canonical.Canonicalizer
+	// Tagger tags jobs with their technologies and seniority.
+	Tagger *tagging.Tagger
+	// BreakerThreshold is how many consecutive failed cycles open a feed's
+	// circuit. Zero disables the breaker.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// BreakerCache persists the breaker's circuits between runs. Nil keeps
+	// them in memory only.
+	BreakerCache cache.Cache
+	// DescriptionLimit caps descriptions, in characters. Zero disables it.
+	DescriptionLimit int
+	// DedupMode enables the cross-source duplicate pass. Empty disables it.
+	DedupMode DedupMode
+	// DedupThreshold is the description similarity, from 0 to 1, above
+	// which two jobs with the same fingerprint are the same role.
+	DedupThreshold float64
+}
+
+func WithFeeds(feeds ...Feed) Option {
+	return func(o *Options) {
+		o.Feeds = feeds
+	}
+}
+
+// WithScrapers provides the scrapers for feeds that select their own scraper type.
+func WithScrapers(scrapers map[scraper.ScraperType]scraper.Scraper) Option {
+	return func(o *Options) {
+		o.Scrapers = scrapers
+	}
+}
+
+// WithEnricher fills in structured fields from each new job's detail page.
+func WithEnricher(e enricher.Enricher) Option {
+	return func(o *Options) {
+		o.Enricher = e
+	}
+}
+
+// WithEnrichTimeout bounds each detail page fetch. Defaults to 20s.
+func WithEnrichTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.EnrichTimeout = d
+	}
+}
+
+// WithFilterRules drops new jobs that do not pass the rules before they are written.
+func WithFilterRules(rules *FilterRules) Option {
+	return func(o *Options) {
+		o.Filters = rules
+	}
+}
+
+// WithNotifier announces the jobs written in each cycle.
+func WithNotifier(n notifier.Notifier) Option {
+	return func(o *Options) {
+		o.Notifier = n
+	}
+}
+
+// WithOutbox keeps jobs durable from just before the write until it succeeds,
+// and replays leftovers at the start of the next cycle.
+func WithOutbox(box outbox.Outbox) Option {
+	return func(o *Options) {
+		o.Outbox = box
+	}
+}
+
+// WithSchedule sets when periodic hunts run. Defaults to daily at 08:00 UTC.
+func WithSchedule(schedule *Schedule) Option {
+	return func(o *Options) {
+		o.Schedule = schedule
+	}
+}
+
+// WithCircuitBreaker skips a feed for cooldown once it has failed threshold
+// cycles in a row. Defaults to 3 failures and a 24h cooldown.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(o *Options) {
+		o.BreakerThreshold = threshold
+		o.BreakerCooldown = cooldown
+	}
+}
+
+// WithBreakerCache persists the circuit breaker in c, so that runs which
+// exit after one cycle, like hunt --once, still open and close circuits.
+func WithBreakerCache(c cache.Cache) Option {
+	return func(o *Options) {
+		o.BreakerCache = c
+	}
+}
+
+// WithCanonicalizer sets how links are canonicalized. Defaults to the
+// built-in rules with no per-host rules.
+func WithCanonicalizer(c *canonical.Canonicalizer) Option {
+	return func(o *Options) {
+		o.Canonicalizer = c
+	}
+}
+
+// WithDescriptionLimit cuts longer descriptions at a sentence boundary.
+// Defaults to 45,000 characters, under the 50,000 a Sheets cell holds.
+func WithDescriptionLimit(n int) Option {
+	return func(o *Options) {
+		o.DescriptionLimit = n
+	}
+}
+
+// WithTagger sets how jobs are tagged. Defaults to the built-in dictionary.
+func WithTagger(t *tagging.Tagger) Option {
+	return func(o *Options) {
+		o.Tagger = t
+	}
+}
+
+// WithFuzzyDedup merges or flags jobs from different sources that look like
+// the same role.
+func WithFuzzyDedup(mode DedupMode, threshold float64) Option {
+	return func(o *Options) {
+		o.DedupMode = mode
+		o.DedupThreshold = threshold
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		Schedule:         defaultSchedule(),
+		Canonicalizer:    canonical.New(),
+		Tagger:           tagging.New(),
+		BreakerThreshold: 3,
+		BreakerCooldown:  24 * time.Hour,
+		DedupThreshold:   0.8,
+		DescriptionLimit: 45000,
+		EnrichTimeout:    20 * time.Second,
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
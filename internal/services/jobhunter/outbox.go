@@ -0,0 +1,109 @@
+package jobhunter
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/w-h-a/scraper/internal/clients/outbox"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// replay returns the jobs earlier cycles failed to write. Entries whose link
+// is already stored did land after all and are dropped from the outbox.
+func (s *Service) replay(ctx context.Context, existingLinks map[string]bool) []JobPost {
+	ctx, span := s.tracer.Start(ctx, "replayOutbox")
+	defer span.End()
+
+	entries, err := s.options.Outbox.Pending(ctx)
+	if err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "failed to read outbox", "error", err)
+		return nil
+	}
+
+	var keys []string
+	for _, entry := range entries {
+		keys = append(keys, s.options.Canonicalizer.Canonicalize(entry.Key))
+	}
+
+	// a failed lookup only costs rewriting entries the store skips
+	stored, err := s.storedLinks(ctx, keys)
+	if err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "failed to look up outbox links", "error", err)
+	}
+
+	var jobs []JobPost
+	var stale []string
+
+	for i, entry := range entries {
+		if existingLinks[keys[i]] || stored[keys[i]] {
+			stale = append(stale, entry.Key)
+			continue
+		}
+
+		var job JobPost
+		if err := json.Unmarshal(entry.Payload, &job); err != nil {
+			slog.WarnContext(ctx, "dropping unreadable outbox entry", "link", entry.Key, "error", err)
+			stale = append(stale, entry.Key)
+			continue
+		}
+
+		// entries stashed before links were canonicalized that fail to be
+		// released under the new key are pruned as stale next cycle
+		job.Link = s.options.Canonicalizer.Canonicalize(job.Link)
+		for i, link := range job.AlsoListedAt {
+			job.AlsoListedAt[i] = s.options.Canonicalizer.Canonicalize(link)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if err := s.options.Outbox.Remove(ctx, stale); err != nil {
+		span.RecordError(err)
+		slog.WarnContext(ctx, "failed to prune outbox", "error", err)
+	}
+
+	span.SetAttributes(
+		attribute.Int("outbox.pending", len(entries)),
+		attribute.Int("outbox.replayed", len(jobs)),
+	)
+
+	if len(jobs) > 0 {
+		slog.InfoContext(ctx, "replaying jobs from outbox", "count", len(jobs))
+	}
+
+	return jobs
+}
+
+// stash saves jobs before they are written. A failing outbox is logged but
+// does not hold up the write.
+func (s *Service) stash(ctx context.Context, jobs []JobPost) {
+	entries := make([]outbox.Entry, 0, len(jobs))
+
+	for _, job := range jobs {
+		payload, err := json.Marshal(job)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, outbox.Entry{Key: job.Link, Payload: payload})
+	}
+
+	if err := s.options.Outbox.Put(ctx, entries); err != nil {
+		slog.WarnContext(ctx, "failed to save jobs to outbox", "count", len(entries), "error", err)
+	}
+}
+
+// release drops jobs from the outbox once they are written.
+func (s *Service) release(ctx context.Context, jobs []JobPost) {
+	keys := make([]string, 0, len(jobs))
+
+	for _, job := range jobs {
+		keys = append(keys, job.Link)
+	}
+
+	if err := s.options.Outbox.Remove(ctx, keys); err != nil {
+		slog.WarnContext(ctx, "failed to remove written jobs from outbox", "count", len(keys), "error", err)
+	}
+}
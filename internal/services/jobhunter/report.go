@@ -0,0 +1,155 @@
+package jobhunter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+type ReportFormat string
+
+const (
+	ReportTable ReportFormat = "table"
+	ReportJSON  ReportFormat = "json"
+	ReportCSV   ReportFormat = "csv"
+)
+
+var ReportFormats = map[string]ReportFormat{
+	"table": ReportTable,
+	"json":  ReportJSON,
+	"csv":   ReportCSV,
+}
+
+// reportCellWidth keeps table cells readable; descriptions run to thousands of characters.
+const reportCellWidth = 60
+
+// WriteReport prints the rows a cycle would have written, followed by the
+// per-source summary. CSV output holds only the rows so that it stays
+// loadable; its summary goes to summaryOut instead.
+func WriteReport(out io.Writer, summaryOut io.Writer, format ReportFormat, rows [][]any, sources []SourceStatus) error {
+	switch format {
+	case ReportTable:
+		return writeTableReport(out, rows, sources)
+	case ReportJSON:
+		return writeJSONReport(out, rows, sources)
+	case ReportCSV:
+		if err := writeCSVReport(out, rows); err != nil {
+			return err
+		}
+		return writeSummaryTable(summaryOut, sources)
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+func writeTableReport(out io.Writer, rows [][]any, sources []SourceStatus) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(Columns, "\t"))
+
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = truncateCell(flattenCell(cell), reportCellWidth)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d row(s) would be written\n\n", len(rows))
+
+	return writeSummaryTable(out, sources)
+}
+
+func writeSummaryTable(out io.Writer, sources []SourceStatus) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "source\tnew\tduplicates\tfiltered_out\terror")
+
+	for _, source := range sources {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\n",
+			source.Source,
+			source.New,
+			source.Duplicates,
+			source.FilteredOut,
+			truncateCell(flattenCell(source.Error), reportCellWidth),
+		)
+	}
+
+	return tw.Flush()
+}
+
+func writeJSONReport(out io.Writer, rows [][]any, sources []SourceStatus) error {
+	report := struct {
+		Rows    []map[string]any `json:"rows"`
+		Summary []SourceStatus   `json:"summary"`
+	}{
+		Rows:    make([]map[string]any, 0, len(rows)),
+		Summary: sources,
+	}
+
+	for _, row := range rows {
+		record := make(map[string]any, len(row))
+		for i, cell := range row {
+			record[columnName(i)] = cell
+		}
+		report.Rows = append(report.Rows, record)
+	}
+
+	if report.Summary == nil {
+		report.Summary = []SourceStatus{}
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(report)
+}
+
+func writeCSVReport(out io.Writer, rows [][]any) error {
+	w := csv.NewWriter(out)
+
+	if err := w.Write(Columns); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = fmt.Sprint(cell)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+func columnName(i int) string {
+	if i < len(Columns) {
+		return Columns[i]
+	}
+	return fmt.Sprintf("column_%d", i+1)
+}
+
+// flattenCell keeps a multi-line cell on one table line.
+func flattenCell(cell any) string {
+	return strings.Join(strings.Fields(fmt.Sprint(cell)), " ")
+}
+
+func truncateCell(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,50 @@
+package jobhunter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/w-h-a/scraper/internal/salary"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// extractSalaries fills the salary fields from the title and description of
+// jobs whose source gave no structured salary.
+func (s *Service) extractSalaries(ctx context.Context, jobs []JobPost) {
+	_, span := s.tracer.Start(ctx, "extractSalaries")
+	defer span.End()
+
+	extracted := 0
+
+	for i := range jobs {
+		job := &jobs[i]
+
+		if job.SalaryMin > 0 || job.SalaryMax > 0 {
+			continue
+		}
+
+		pay, ok := salary.Extract(job.JobTitle + "\n" + job.RawDescription)
+		if !ok {
+			continue
+		}
+
+		job.SalaryMin = pay.Min
+		job.SalaryMax = pay.Max
+		job.SalaryCurrency = pay.Currency
+		job.SalaryPeriod = pay.Period
+
+		extracted++
+	}
+
+	span.SetAttributes(attribute.Int("jobs.salary_extracted", extracted))
+}
+
+// customAmount reads a structured salary bound a scraper left on the item,
+// treating a missing or malformed value as unknown.
+func customAmount(value string) float64 {
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount < 0 {
+		return 0
+	}
+	return amount
+}
@@ -0,0 +1,69 @@
+package jobhunter
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	DefaultScheduleExpression = "0 8 * * *"
+	DefaultScheduleTimezone   = "UTC"
+)
+
+// Schedule decides when periodic hunts run: a standard five field cron
+// expression evaluated in a timezone, plus an optional random delay.
+type Schedule struct {
+	expression string
+	spec       cron.Schedule
+	location   *time.Location
+	jitter     time.Duration
+}
+
+func NewSchedule(expression string, timezone string, jitter time.Duration) (*Schedule, error) {
+	spec, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expression, err)
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule timezone %q: %w", timezone, err)
+	}
+
+	if jitter < 0 {
+		return nil, fmt.Errorf("schedule jitter must not be negative, got %s", jitter)
+	}
+
+	return &Schedule{
+		expression: expression,
+		spec:       spec,
+		location:   location,
+		jitter:     jitter,
+	}, nil
+}
+
+// Next returns the first run after now, including jitter.
+func (s *Schedule) Next(now time.Time) time.Time {
+	next := s.spec.Next(now.In(s.location))
+
+	if s.jitter > 0 {
+		next = next.Add(rand.N(s.jitter))
+	}
+
+	return next
+}
+
+func (s *Schedule) String() string {
+	return fmt.Sprintf("%s (%s)", s.expression, s.location)
+}
+
+func defaultSchedule() *Schedule {
+	s, err := NewSchedule(DefaultScheduleExpression, DefaultScheduleTimezone, 0)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
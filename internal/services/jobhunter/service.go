@@ -11,18 +11,31 @@ import (
 	"github.com/w-h-a/scraper/internal/clients/reader"
 	"github.com/w-h-a/scraper/internal/clients/readwriter"
 	"github.com/w-h-a/scraper/internal/clients/scraper"
+	"github.com/w-h-a/scraper/internal/clients/writer"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// cycleTimeout bounds one hunt. It outlasts the Sheets writer's retry budget
+// for a chunk, four backoffs of up to a minute each, with room to scrape, and
+// stays under the CronJob's ten minute deadline.
+const cycleTimeout = 8 * time.Minute
+
 type Service struct {
+	options    Options
 	scraper    scraper.Scraper
 	readwriter readwriter.ReadWriter
 	tracer     trace.Tracer
+	metrics    *metrics
+	breaker    *circuitBreaker
 	wg         sync.WaitGroup
 	exit       chan struct{}
 	isRunning  bool
+	nextRun    time.Time
+	hunting    bool
+	ready      bool
+	lastCycle  *CycleStatus
 	mtx        sync.RWMutex
 }
 
@@ -47,54 +60,116 @@ func (s *Service) Start() error {
 	s.isRunning = true
 	s.exit = make(chan struct{})
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-		s.hunt()
-	}()
-
 	s.wg.Add(1)
 	go s.periodicHunt()
 
 	return nil
 }
 
+// periodicHunt runs one hunt straight away and then one at every scheduled
+// time. Runs are computed from the wall clock, so restarts do not shift them.
 func (s *Service) periodicHunt() {
 	defer s.wg.Done()
 
-	tick := time.NewTicker(24 * time.Hour)
-	defer tick.Stop()
+	next := s.scheduleNext()
+	s.launchHunt()
 
 huntLoop:
 	for {
+		timer := time.NewTimer(time.Until(next))
+
 		select {
 		case <-s.exit:
+			timer.Stop()
 			break huntLoop
-		case <-tick.C:
-			s.wg.Add(1)
-			go func() {
-				defer s.wg.Done()
-				s.hunt()
-			}()
+		case <-timer.C:
+			next = s.scheduleNext()
+			s.launchHunt()
 		}
 	}
 }
 
+func (s *Service) scheduleNext() time.Time {
+	next := s.options.Schedule.Next(time.Now())
+
+	s.mtx.Lock()
+	s.nextRun = next
+	s.mtx.Unlock()
+
+	slog.InfoContext(context.Background(), "next job hunt scheduled", "at", next, "schedule", s.options.Schedule.String())
+
+	return next
+}
+
+func (s *Service) launchHunt() {
+	if err := s.TriggerHunt(); err != nil {
+		slog.WarnContext(context.Background(), "skipping scheduled job hunt", "error", err)
+	}
+}
+
+// TriggerHunt starts a hunt in the background. It returns ErrNotRunning
+// before Start or once Stop has begun, and ErrHuntInProgress while another
+// hunt is in flight. The hunt is added to the wait group under the same lock
+// that Stop takes, so Stop always waits for it.
+func (s *Service) TriggerHunt() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.isRunning {
+		return ErrNotRunning
+	}
+
+	if s.hunting {
+		return ErrHuntInProgress
+	}
+
+	s.hunting = true
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.endCycle()
+		s.hunt()
+	}()
+
+	return nil
+}
+
+// NextRun returns when the next periodic hunt is due.
+func (s *Service) NextRun() time.Time {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.nextRun
+}
+
 func (s *Service) hunt() {
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	s.Hunt(context.Background())
+}
+
+// Hunt runs one cycle under the JobHuntCycle span with the cycle timeout.
+// Periodic and triggered hunts go through it, as does run-once mode.
+func (s *Service) Hunt(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, cycleTimeout)
 	defer cancel()
 
 	ctx, span := s.tracer.Start(ctx, "JobHuntCycle")
 	defer span.End()
 
+	if next := s.NextRun(); !next.IsZero() {
+		span.SetAttributes(attribute.String("schedule.next_run", next.Format(time.RFC3339)))
+	}
+
 	if err := s.ExecuteJobHunt(ctx); err != nil {
 		slog.ErrorContext(ctx, "job hunt failed", "error", err)
 		span.RecordError(err)
-		return
+		return err
 	}
 
 	slog.InfoContext(ctx, "job hunt complete")
 	span.AddEvent("JobHuntCompleted")
+
+	return nil
 }
 
 func (s *Service) Stop() error {
@@ -137,32 +212,75 @@ func (s *Service) stop(ctx context.Context) error {
 	return stopErr
 }
 
-func (s *Service) ExecuteJobHunt(ctx context.Context) error {
+func (s *Service) ExecuteJobHunt(ctx context.Context) (err error) {
 	ctx, span := s.tracer.Start(ctx, "ExecuteJobHunt")
 	defer span.End()
 
+	cycle := &CycleStatus{StartedAt: time.Now()}
+	defer func() {
+		s.recordCycle(cycle, err)
+		s.metrics.recordCycle(ctx, cycle)
+	}()
+
 	span.AddEvent("JobHuntStarted")
 
 	const linkReadRange = "A:D"
 
 	existingLinks, err := s.readwriter.ReadExisting(ctx, reader.ReadExistingWithQuery(linkReadRange))
+	s.setReady(err == nil)
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to read existing links: %s", err)
 	}
 
+	// rows written before links were canonicalized still hold raw links, both
+	// their own and those merged into them, so every store's links are
+	// canonicalized here, once
+	existingLinks = s.options.Canonicalizer.Links(existingLinks)
+
 	span.SetAttributes(attribute.Int("deduplication.set.size", len(existingLinks)))
 
+	var replayed []JobPost
+
+	if s.options.Outbox != nil {
+		replayed = s.replay(ctx, existingLinks)
+
+		cycle.JobsReplayed = len(replayed)
+
+		// replayed jobs are already on their way, so do not scrape them again
+		if len(replayed) > 0 {
+			known := make(map[string]bool, len(existingLinks)+len(replayed))
+			for link := range existingLinks {
+				known[link] = true
+			}
+			for _, job := range replayed {
+				known[job.Link] = true
+			}
+			existingLinks = known
+		}
+	}
+
+	feeds := enabledFeeds(s.options.Feeds)
+
+	tally := newSourceTally(feeds)
+	defer func() { cycle.Sources = tally.snapshot() }()
+
+	// a failed restore keeps the circuits of earlier cycles in this process
+	if err := s.breaker.restore(ctx); err != nil {
+		slog.WarnContext(ctx, "failed to restore feed circuits", "error", err)
+		span.RecordError(err)
+	}
+
 	var wg sync.WaitGroup
 	jobChan := make(chan JobPost, 100)
-	errChan := make(chan error, len(RSSFeeds))
+	errChan := make(chan error, len(feeds))
 
 	feedCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	for source, url := range RSSFeeds {
+	for _, feed := range feeds {
 		wg.Add(1)
-		go s.processFeed(feedCtx, source, url, existingLinks, jobChan, errChan, &wg)
+		go s.processFeed(feedCtx, feed, existingLinks, tally, jobChan, errChan, &wg)
 	}
 
 	go func() {
@@ -172,8 +290,15 @@ func (s *Service) ExecuteJobHunt(ctx context.Context) error {
 		span.AddEvent("AllFeedsProcessed")
 	}()
 
+	// two feeds can carry the same posting under different raw links
 	var newJobs []JobPost
+	seen := map[string]bool{}
 	for job := range jobChan {
+		if seen[job.Link] {
+			tally.duplicated(job.Source)
+			continue
+		}
+		seen[job.Link] = true
 		newJobs = append(newJobs, job)
 	}
 
@@ -182,10 +307,18 @@ func (s *Service) ExecuteJobHunt(ctx context.Context) error {
 		feedErrors = append(feedErrors, err)
 	}
 
-	feedsTotal := len(RSSFeeds)
+	if err := s.breaker.persist(ctx); err != nil {
+		slog.WarnContext(ctx, "failed to persist feed circuits", "error", err)
+		span.RecordError(err)
+	}
+
+	feedsTotal := len(feeds)
 	feedsFailed := len(feedErrors)
 	feedsSucceeded := feedsTotal - feedsFailed
 
+	cycle.FeedsSucceeded = feedsSucceeded
+	cycle.FeedsFailed = feedsFailed
+
 	span.SetAttributes(
 		attribute.Int("feeds.succeeded", feedsSucceeded),
 		attribute.Int("feeds.failed", feedsFailed),
@@ -194,6 +327,9 @@ func (s *Service) ExecuteJobHunt(ctx context.Context) error {
 	if feedsFailed > 0 && feedsFailed == feedsTotal {
 		err := fmt.Errorf("all %d feeds failed: %w", feedsTotal, errors.Join(feedErrors...))
 		span.RecordError(err)
+		if len(replayed) > 0 {
+			return errors.Join(err, s.deliver(ctx, cycle, tally, replayed, nil))
+		}
 		return err
 	}
 
@@ -209,21 +345,162 @@ func (s *Service) ExecuteJobHunt(ctx context.Context) error {
 
 	if len(newJobs) == 0 {
 		span.AddEvent("NoNewJobsFound")
-		return nil
+		return s.commit(ctx, feeds, s.deliver(ctx, cycle, tally, replayed, nil))
 	}
 
 	span.SetAttributes(attribute.Int("jobs.newly_found", len(newJobs)))
 
-	rowsToAppend := s.convertJobPostsToGenericRows(newJobs)
+	cycle.JobsFound = len(newJobs)
+
+	if s.options.Filters != nil {
+		before := newJobs
+
+		newJobs = s.filter(ctx, newJobs, true)
+
+		tally.filtered(before, newJobs)
+	}
+
+	if s.options.Enricher != nil {
+		s.enrich(ctx, newJobs)
+	}
+
+	s.plainText(ctx, newJobs)
+
+	s.extractSalaries(ctx, newJobs)
+
+	s.classifyLocations(ctx, newJobs)
+
+	s.tag(ctx, newJobs)
+
+	if s.options.Filters != nil {
+		before := newJobs
+
+		newJobs = s.filter(ctx, newJobs, false)
+
+		tally.filtered(before, newJobs)
+
+		span.SetAttributes(attribute.Int("jobs.filtered_out", cycle.JobsFound-len(newJobs)))
+
+		cycle.JobsFilteredOut = cycle.JobsFound - len(newJobs)
+
+		if len(newJobs) == 0 {
+			span.AddEvent("AllNewJobsFilteredOut")
+		}
+	}
 
-	return s.readwriter.WriteBatch(ctx, rowsToAppend)
+	if len(s.options.DedupMode) > 0 {
+		newJobs = s.dedupe(ctx, newJobs, tally)
+	}
+
+	return s.commit(ctx, feeds, s.deliver(ctx, cycle, tally, replayed, newJobs))
+}
+
+// commit lets the scrapers of feeds keep their state, such as the validators
+// for conditional requests, once the cycle's jobs have been delivered. After a
+// failed delivery nothing is committed, so the next cycle scrapes the feeds in
+// full and finds the jobs that were not written. It returns deliverErr.
+func (s *Service) commit(ctx context.Context, feeds []Feed, deliverErr error) error {
+	if deliverErr != nil {
+		return deliverErr
+	}
+
+	for _, feed := range feeds {
+		sc, err := s.scraperFor(feed)
+		if err != nil {
+			continue
+		}
+
+		committer, ok := sc.(scraper.Committer)
+		if !ok {
+			continue
+		}
+
+		if err := committer.Commit(ctx, feed.URL); err != nil {
+			slog.WarnContext(ctx, "failed to commit feed state", "source", feed.Name, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// deliver writes replayed and freshly scraped jobs through the outbox, when
+// one is configured, and announces whatever landed. Jobs the store skipped
+// as already held are released from the outbox but neither counted as
+// written nor announced, and fresh ones count as duplicates of their source.
+func (s *Service) deliver(ctx context.Context, cycle *CycleStatus, tally *sourceTally, replayed []JobPost, fresh []JobPost) error {
+	jobs := append(replayed[:len(replayed):len(replayed)], fresh...)
+
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	span := trace.SpanFromContext(ctx)
+
+	if s.options.Outbox != nil {
+		s.stash(ctx, jobs)
+	}
+
+	rowsToAppend := s.convertJobPostsToGenericRows(jobs)
+
+	writeStart := time.Now()
+	result, err := s.readwriter.WriteBatch(ctx, rowsToAppend)
+	s.metrics.recordWrite(ctx, time.Since(writeStart), err)
+
+	written := 0
+	if err == nil {
+		written = len(jobs)
+	}
+
+	var partial *writer.PartialWriteError
+	isPartial := errors.As(err, &partial)
+
+	if isPartial {
+		written = partial.Written
+		span.SetAttributes(attribute.Int("jobs.written", written))
+	}
+
+	s.setReady(err == nil || isPartial)
+
+	if s.options.Outbox != nil {
+		s.release(ctx, jobs[:written])
+	}
+
+	skipped := make(map[string]bool, len(result.Skipped))
+	for _, link := range result.Skipped {
+		skipped[link] = true
+	}
+
+	var inserted []JobPost
+	for i, job := range jobs[:written] {
+		if !skipped[job.Link] {
+			inserted = append(inserted, job)
+			continue
+		}
+		if i >= len(replayed) {
+			tally.duplicated(job.Source)
+		}
+	}
+
+	if len(skipped) > 0 {
+		span.SetAttributes(attribute.Int("jobs.skipped", len(jobs[:written])-len(inserted)))
+	}
+
+	cycle.JobsWritten = len(inserted)
+
+	// rows that landed will be deduped next cycle, so announce them even when
+	// the rest of the batch failed
+	if s.options.Notifier != nil && len(inserted) > 0 {
+		s.notify(ctx, inserted)
+	}
+
+	return err
 }
 
 func (s *Service) processFeed(
 	ctx context.Context,
-	sourceName string,
-	url string,
+	source Feed,
 	existingLinks map[string]bool,
+	tally *sourceTally,
 	jobChan chan<- JobPost,
 	errChan chan<- error,
 	wg *sync.WaitGroup,
@@ -234,21 +511,82 @@ func (s *Service) processFeed(
 	defer span.End()
 
 	span.SetAttributes(
-		attribute.String("feed.source", sourceName),
-		attribute.String("feed.url", url),
+		attribute.String("feed.source", source.Name),
+		attribute.String("feed.url", source.URL),
+		attribute.StringSlice("feed.tags", source.Tags),
+		attribute.String("feed.scraper", string(source.Scraper)),
 	)
 
-	feed, err := s.scraper.Scrape(ctx, url)
+	circuit := s.breaker.allow(source.Name, time.Now())
+
+	span.SetAttributes(attribute.String("feed.circuit", string(circuit)))
+
+	if circuit == circuitOpen {
+		err := fmt.Errorf("feed %s: %w", source.Name, ErrCircuitOpen)
+		slog.WarnContext(ctx, "skipping feed with open circuit", "source", source.Name)
+		s.metrics.recordFeedError(ctx, source.Name, err)
+		span.AddEvent("FeedCircuitOpen")
+		tally.failed(source.Name, err)
+		errChan <- err
+		return
+	}
+
+	start := time.Now()
+
+	sc, err := s.scraperFor(source)
+	if err != nil {
+		s.metrics.recordFeed(ctx, source.Name, time.Since(start), 0, err)
+		span.RecordError(err)
+		tally.failed(source.Name, err)
+		errChan <- fmt.Errorf("feed %s: %w", source.Name, err)
+		return
+	}
+
+	feed, err := sc.Scrape(ctx, source.URL)
+	fetchDuration := time.Since(start)
+
+	if opened := s.breaker.record(source.Name, err, time.Now()); opened {
+		slog.WarnContext(ctx, "feed circuit opened", "source", source.Name, "threshold", s.options.BreakerThreshold, "cooldown", s.options.BreakerCooldown)
+		span.AddEvent("FeedCircuitOpened")
+	} else if err == nil && circuit == circuitHalfOpen {
+		slog.InfoContext(ctx, "feed circuit closed", "source", source.Name)
+		span.AddEvent("FeedCircuitClosed")
+	}
+
 	if err != nil {
+		s.metrics.recordFeed(ctx, source.Name, fetchDuration, 0, err)
 		span.RecordError(err)
-		errChan <- fmt.Errorf("feed %s: %w", sourceName, err)
+		tally.failed(source.Name, err)
+		errChan <- fmt.Errorf("feed %s: %w", source.Name, err)
+		return
+	}
+
+	var candidates []string
+	for _, item := range feed.Items {
+		if link := s.options.Canonicalizer.Canonicalize(item.Link); !existingLinks[link] {
+			candidates = append(candidates, link, item.Link)
+		}
+	}
+
+	stored, err := s.storedLinks(ctx, candidates)
+	if err != nil {
+		err = fmt.Errorf("failed to look up stored links: %w", err)
+		s.metrics.recordFeed(ctx, source.Name, fetchDuration, 0, err)
+		span.RecordError(err)
+		tally.failed(source.Name, err)
+		errChan <- fmt.Errorf("feed %s: %w", source.Name, err)
 		return
 	}
 
 	newCount := 0
+	duplicates := 0
 
 	for _, item := range feed.Items {
-		if existingLinks[item.Link] {
+		link := s.options.Canonicalizer.Canonicalize(item.Link)
+
+		// rows written before links were canonicalized hold the raw link
+		if existingLinks[link] || stored[link] || stored[item.Link] {
+			duplicates++
 			continue
 		}
 
@@ -270,11 +608,19 @@ func (s *Service) processFeed(
 
 		jobPost := JobPost{
 			DatePosted:     dateString,
-			Source:         sourceName,
+			Source:         source.Name,
 			JobTitle:       item.Title,
-			Link:           item.Link,
+			Link:           link,
 			RawDescription: rawContent,
 			Status:         "New",
+			Team:           item.Custom["team"],
+			Location:       item.Custom["location"],
+			EmploymentType: item.Custom["commitment"],
+			Workplace:      item.Custom["workplace_type"],
+			SalaryMin:      customAmount(item.Custom["salary_min"]),
+			SalaryMax:      customAmount(item.Custom["salary_max"]),
+			SalaryCurrency: item.Custom["salary_currency"],
+			SalaryPeriod:   item.Custom["salary_period"],
 		}
 
 		jobChan <- jobPost
@@ -282,32 +628,86 @@ func (s *Service) processFeed(
 		newCount++
 	}
 
+	s.metrics.recordFeed(ctx, source.Name, fetchDuration, newCount, nil)
+	tally.scraped(source.Name, newCount, duplicates)
+
 	span.SetAttributes(attribute.Int("jobs.scraped_new", newCount))
 	span.AddEvent("FeedProcessingFinished", trace.WithAttributes(attribute.Int("items.added", newCount)))
 }
 
+// storedLinks asks the store which of links it already holds, when its
+// ReadExisting leaves them out. Other stores answer through ReadExisting.
+func (s *Service) storedLinks(ctx context.Context, links []string) (map[string]bool, error) {
+	looker, ok := s.readwriter.(reader.LinkLooker)
+	if !ok || len(links) == 0 {
+		return nil, nil
+	}
+
+	return looker.LookupLinks(ctx, links)
+}
+
+func (s *Service) scraperFor(source Feed) (scraper.Scraper, error) {
+	if len(source.Scraper) == 0 {
+		return s.scraper, nil
+	}
+
+	sc, ok := s.options.Scrapers[source.Scraper]
+	if !ok {
+		return nil, fmt.Errorf("no scraper configured for type %q", source.Scraper)
+	}
+
+	return sc, nil
+}
+
 func (s *Service) convertJobPostsToGenericRows(jobs []JobPost) [][]any {
 	rows := make([][]any, len(jobs))
 
 	for i, job := range jobs {
 		rows[i] = []any{
 			job.DatePosted,
-			job.Source,
+			sourceCell(job),
 			job.JobTitle,
 			job.Link,
 			job.RawDescription,
 			job.Status,
+			job.Team,
+			job.Location,
+			job.EmploymentType,
+			job.Company,
+			salaryCell(job.SalaryMin),
+			salaryCell(job.SalaryMax),
+			job.SalaryCurrency,
+			job.SalaryPeriod,
+			job.Workplace,
+			job.ValidThrough,
+			alsoListedCell(job.AlsoListedAt),
+			job.Regions,
+			job.Timezone,
+			tagsCell(job),
 		}
 	}
 
 	return rows
 }
 
-func New(scraper scraper.Scraper, readwriter readwriter.ReadWriter) *Service {
+// salaryCell leaves the cell blank when no salary is known.
+func salaryCell(amount float64) any {
+	if amount == 0 {
+		return ""
+	}
+	return amount
+}
+
+func New(scraper scraper.Scraper, readwriter readwriter.ReadWriter, opts ...Option) *Service {
+	options := NewOptions(opts...)
+
 	return &Service{
+		options:    options,
 		scraper:    scraper,
 		readwriter: readwriter,
 		tracer:     otel.Tracer("job-hunter"),
+		metrics:    newMetrics(),
+		breaker:    newCircuitBreaker(options.BreakerThreshold, options.BreakerCooldown, options.BreakerCache),
 		wg:         sync.WaitGroup{},
 		isRunning:  false,
 		mtx:        sync.RWMutex{},
@@ -0,0 +1,99 @@
+package jobhunter
+
+import "sync"
+
+// SourceStatus counts what one feed contributed to a cycle.
+type SourceStatus struct {
+	Source      string `json:"source"`
+	New         int    `json:"new"`
+	Duplicates  int    `json:"duplicates"`
+	FilteredOut int    `json:"filtered_out"`
+	Error       string `json:"error,omitempty"`
+}
+
+// sourceTally collects SourceStatus from the concurrent processFeed calls.
+type sourceTally struct {
+	order   []string
+	sources map[string]*SourceStatus
+	mtx     sync.Mutex
+}
+
+func (t *sourceTally) scraped(source string, fresh int, duplicates int) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	status := t.get(source)
+	status.New += fresh
+	status.Duplicates += duplicates
+}
+
+// duplicated moves one of the source's new jobs over to duplicates.
+func (t *sourceTally) duplicated(source string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	status := t.get(source)
+	status.New--
+	status.Duplicates++
+}
+
+func (t *sourceTally) failed(source string, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.get(source).Error = err.Error()
+}
+
+// filtered counts, per source, the jobs present in before but not in after.
+func (t *sourceTally) filtered(before []JobPost, after []JobPost) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	kept := map[string]int{}
+	for _, job := range after {
+		kept[job.Source]++
+	}
+
+	for _, job := range before {
+		if kept[job.Source] > 0 {
+			kept[job.Source]--
+			continue
+		}
+		t.get(job.Source).FilteredOut++
+	}
+}
+
+func (t *sourceTally) snapshot() []SourceStatus {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	statuses := make([]SourceStatus, 0, len(t.order))
+	for _, source := range t.order {
+		statuses = append(statuses, *t.sources[source])
+	}
+
+	return statuses
+}
+
+func (t *sourceTally) get(source string) *SourceStatus {
+	status, ok := t.sources[source]
+	if !ok {
+		status = &SourceStatus{Source: source}
+		t.sources[source] = status
+		t.order = append(t.order, source)
+	}
+
+	return status
+}
+
+func newSourceTally(feeds []Feed) *sourceTally {
+	t := &sourceTally{
+		sources: map[string]*SourceStatus{},
+	}
+
+	for _, feed := range feeds {
+		t.get(feed.Name)
+	}
+
+	return t
+}
@@ -0,0 +1,105 @@
+package jobhunter
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrHuntInProgress = errors.New("job hunt already in progress")
+	ErrNotRunning     = errors.New("job hunter not running")
+)
+
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomePartial Outcome = "partial"
+	OutcomeFailed  Outcome = "failed"
+)
+
+// CycleStatus summarizes one run of ExecuteJobHunt.
+type CycleStatus struct {
+	StartedAt       time.Time      `json:"started_at"`
+	FinishedAt      time.Time      `json:"finished_at"`
+	Outcome         Outcome        `json:"outcome"`
+	Error           string         `json:"error,omitempty"`
+	FeedsSucceeded  int            `json:"feeds_succeeded"`
+	FeedsFailed     int            `json:"feeds_failed"`
+	JobsFound       int            `json:"jobs_found"`
+	JobsFilteredOut int            `json:"jobs_filtered_out"`
+	JobsReplayed    int            `json:"jobs_replayed"`
+	Sources         []SourceStatus `json:"sources,omitempty"`
+	JobsWritten     int            `json:"jobs_written"`
+}
+
+type Status struct {
+	Hunting   bool         `json:"hunting"`
+	Ready     bool         `json:"ready"`
+	NextRun   time.Time    `json:"next_run,omitzero"`
+	LastCycle *CycleStatus `json:"last_cycle,omitempty"`
+	// OpenCircuits lists the feeds currently skipped by the circuit breaker.
+	OpenCircuits []string `json:"open_circuits,omitempty"`
+}
+
+// Status reports whether a cycle is in flight, whether the readwriter was
+// reachable on the last attempt and how the last cycle went.
+func (s *Service) Status() Status {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	status := Status{
+		Hunting:      s.hunting,
+		Ready:        s.ready,
+		NextRun:      s.nextRun,
+		OpenCircuits: s.breaker.open(),
+	}
+
+	if s.lastCycle != nil {
+		last := *s.lastCycle
+		status.LastCycle = &last
+	}
+
+	return status
+}
+
+// Ready reports whether the readwriter was reachable on the last attempt.
+func (s *Service) Ready() bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.ready
+}
+
+func (s *Service) setReady(ready bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.ready = ready
+}
+
+func (s *Service) endCycle() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.hunting = false
+}
+
+func (s *Service) recordCycle(cycle *CycleStatus, err error) {
+	cycle.FinishedAt = time.Now()
+
+	switch {
+	case err != nil:
+		cycle.Outcome = OutcomeFailed
+		cycle.Error = err.Error()
+	case cycle.FeedsFailed > 0:
+		cycle.Outcome = OutcomePartial
+	default:
+		cycle.Outcome = OutcomeSuccess
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.lastCycle = cycle
+}
@@ -0,0 +1,50 @@
+package jobhunter
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tag tags each job with the technologies it mentions and its seniority.
+func (s *Service) tag(ctx context.Context, jobs []JobPost) {
+	_, span := s.tracer.Start(ctx, "tagJobs")
+	defer span.End()
+
+	tagged, leveled := 0, 0
+
+	for i := range jobs {
+		job := &jobs[i]
+
+		tags := s.options.Tagger.Tag(job.JobTitle, job.RawDescription)
+
+		job.Tags = tags.Technologies
+		job.Seniority = tags.Seniority
+
+		if len(job.Tags) > 0 {
+			tagged++
+		}
+		if len(job.Seniority) > 0 {
+			leveled++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("jobs.tagged", tagged),
+		attribute.Int("jobs.seniority_guessed", leveled),
+	)
+}
+
+// labels are the seniority, when known, followed by the technologies.
+func (j JobPost) labels() []string {
+	if len(j.Seniority) == 0 {
+		return j.Tags
+	}
+	return append([]string{j.Seniority}, j.Tags...)
+}
+
+// tagsCell joins the labels of a job into one cell.
+func tagsCell(job JobPost) string {
+	return strings.Join(job.labels(), ", ")
+}
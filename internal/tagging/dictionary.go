@@ -0,0 +1,130 @@
+package tagging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// technologies is the built-in dictionary of tags and the names each is
+// found by. Names written with capitals match case-sensitively.
+var technologies = map[string][]string{
+	"go":            {"Go", "golang"},
+	"rust":          {"rust"},
+	"python":        {"python"},
+	"java":          {"java"},
+	"kotlin":        {"kotlin"},
+	"scala":         {"scala"},
+	"ruby":          {"ruby"},
+	"rails":         {"rails", "ruby on rails", "RoR"},
+	"php":           {"php"},
+	"elixir":        {"elixir"},
+	"erlang":        {"erlang"},
+	"haskell":       {"haskell"},
+	"clojure":       {"clojure"},
+	"c++":           {"c++", "cpp"},
+	"c#":            {"c#", "csharp"},
+	".net":          {".net", "dotnet"},
+	"typescript":    {"typescript"},
+	"javascript":    {"javascript"},
+	"node.js":       {"node.js", "nodejs", "Node"},
+	"react":         {"React", "react.js", "reactjs"},
+	"vue":           {"Vue", "vue.js", "vuejs"},
+	"angular":       {"angular"},
+	"swift":         {"Swift"},
+	"kubernetes":    {"kubernetes", "k8s"},
+	"docker":        {"docker"},
+	"helm":          {"Helm"},
+	"istio":         {"istio"},
+	"terraform":     {"terraform"},
+	"ansible":       {"ansible"},
+	"aws":           {"aws", "amazon web services"},
+	"gcp":           {"gcp", "google cloud", "google cloud platform"},
+	"azure":         {"azure"},
+	"linux":         {"linux"},
+	"grpc":          {"grpc"},
+	"protobuf":      {"protobuf", "protocol buffers"},
+	"graphql":       {"graphql"},
+	"kafka":         {"kafka"},
+	"rabbitmq":      {"rabbitmq"},
+	"nats":          {"NATS"},
+	"postgres":      {"postgres", "postgresql", "psql"},
+	"mysql":         {"mysql"},
+	"sqlite":        {"sqlite"},
+	"mongodb":       {"mongodb", "mongo"},
+	"redis":         {"redis"},
+	"cassandra":     {"cassandra"},
+	"dynamodb":      {"dynamodb"},
+	"clickhouse":    {"clickhouse"},
+	"elasticsearch": {"elasticsearch", "elastic search", "opensearch"},
+	"snowflake":     {"Snowflake"},
+	"spark":         {"Spark", "apache spark"},
+	"flink":         {"flink"},
+	"airflow":       {"airflow"},
+	"prometheus":    {"prometheus"},
+	"grafana":       {"grafana"},
+	"opentelemetry": {"opentelemetry", "otel"},
+}
+
+// contextual names are also ordinary words, so they only count next to words
+// that make them a language. "Go" alone would tag "Go-to-market Engineer".
+var contextual = map[string]bool{
+	"Go": true,
+}
+
+type dictionaryFile struct {
+	Synonyms map[string][]string `yaml:"synonyms"`
+}
+
+// LoadDictionary reads extra tags and synonyms from a YAML or JSON file.
+func LoadDictionary(path string) ([]Option, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tags dictionary at %s: %w", path, err)
+	}
+
+	return ParseDictionary(data)
+}
+
+// ParseDictionary decodes extra tags and synonyms into options for New.
+func ParseDictionary(data []byte) ([]Option, error) {
+	var file dictionaryFile
+
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tags dictionary: %w", err)
+	}
+
+	var errs []error
+
+	synonyms := make(map[string][]string, len(file.Synonyms))
+
+	for tag, names := range file.Synonyms {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if len(tag) == 0 {
+			errs = append(errs, errors.New("tag names must not be empty"))
+			continue
+		}
+
+		for _, name := range names {
+			name = strings.TrimSpace(name)
+			if len(name) == 0 {
+				errs = append(errs, fmt.Errorf("tag %q: synonyms must not be empty", tag))
+				continue
+			}
+			synonyms[tag] = append(synonyms[tag], name)
+		}
+
+		if _, ok := synonyms[tag]; !ok {
+			synonyms[tag] = []string{}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return []Option{WithSynonyms(synonyms)}, nil
+}
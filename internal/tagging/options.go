@@ -0,0 +1,32 @@
+package tagging
+
+type Option func(*Options)
+
+type Options struct {
+	// Synonyms maps each tag to more names it is found by, on top of the
+	// built-in dictionary. A tag that is not built in is added.
+	Synonyms map[string][]string
+}
+
+// WithSynonyms adds names for tags, e.g. "kubernetes": {"k8s"}. Names written
+// with capitals match case-sensitively, so "Go" does not match "go".
+func WithSynonyms(synonyms map[string][]string) Option {
+	return func(o *Options) {
+		if o.Synonyms == nil {
+			o.Synonyms = map[string][]string{}
+		}
+		for tag, names := range synonyms {
+			o.Synonyms[tag] = append(o.Synonyms[tag], names...)
+		}
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
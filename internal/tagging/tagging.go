@@ -0,0 +1,249 @@
+// Package tagging tags jobs with the technologies they mention and a guess at
+// their seniority.
+package tagging
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Seniority levels, from least to most senior.
+const (
+	Junior = "junior"
+	Mid    = "mid"
+	Senior = "senior"
+	Staff  = "staff"
+	Lead   = "lead"
+)
+
+// Tags are what a job was tagged with. Technologies are sorted; Seniority is
+// empty when the job gives no hint.
+type Tags struct {
+	Technologies []string
+	Seniority    string
+}
+
+type Tagger struct {
+	options Options
+	// caseless and exact match every name in the dictionary, and names
+	// maps each name back to its tag.
+	caseless *regexp.Regexp
+	exact    *regexp.Regexp
+	names    map[string]string
+}
+
+// Tag reads the technologies from the title and description, and the
+// seniority from the title or, failing that, the years of experience the
+// description asks for.
+func (t *Tagger) Tag(title, description string) Tags {
+	text := title + "\n" + description
+
+	found := map[string]bool{}
+
+	for _, re := range []*regexp.Regexp{t.caseless, t.exact} {
+		if re == nil {
+			continue
+		}
+		for _, m := range re.FindAllStringSubmatchIndex(text, -1) {
+			if !wordEnd(text, m[3]) {
+				continue
+			}
+			name := text[m[2]:m[3]]
+			if contextual[name] && !languageContext(text, m[2], m[3]) {
+				continue
+			}
+			tag, ok := t.names[name]
+			if !ok {
+				tag = t.names[normalize(name)]
+			}
+			if len(tag) > 0 {
+				found[tag] = true
+			}
+		}
+	}
+
+	technologies := make([]string, 0, len(found))
+	for tag := range found {
+		technologies = append(technologies, tag)
+	}
+	sort.Strings(technologies)
+
+	seniority := titleSeniority(title)
+	if len(seniority) == 0 {
+		seniority = experienceSeniority(description)
+	}
+
+	return Tags{
+		Technologies: technologies,
+		Seniority:    seniority,
+	}
+}
+
+var (
+	// languageBefore and languageAfter are the neighbours that make a name
+	// read as a programming language: "in Go", "Engineer, Go", "Go engineer",
+	// "Go-based", "(Go)".
+	languageBefore = regexp.MustCompile(`(?i)(?:[(,/&]|\b(?:in|with|using|and|or|of|on))\s*$`)
+	languageAfter  = regexp.MustCompile(`(?i)^(?:\s*(?:$|[),/&])|[\s-]+(?:developers?|engineers?|engineering|programmers?|programming|language|backend|services?|microservices?|code|based|stack)\b)`)
+)
+
+// languageContext reports whether the name at text[start:end] is next to
+// words that make it a language rather than an ordinary word.
+func languageContext(text string, start, end int) bool {
+	return languageBefore.MatchString(text[:start]) || languageAfter.MatchString(text[end:])
+}
+
+// titleLevels are checked in order, so "Senior Tech Lead" is a lead.
+var titleLevels = []struct {
+	level   string
+	pattern *regexp.Regexp
+}{
+	{Lead, regexp.MustCompile(`(?i)\b(?:lead|head\s+of|manager|director|vp|vice\s+president|cto)\b`)},
+	{Staff, regexp.MustCompile(`(?i)\b(?:staff|principal|distinguished|architect)\b`)},
+	{Senior, regexp.MustCompile(`(?i)\b(?:senior|sr\.?|iii|expert)(?:\s|$|[^\pL])`)},
+	{Mid, regexp.MustCompile(`(?i)\b(?:mid(?:[\s-]?level)?|intermediate|ii)\b`)},
+	{Junior, regexp.MustCompile(`(?i)\b(?:junior|jr\.?|graduate|grad|entry[\s-]level|intern(?:ship)?|trainee|apprentice)(?:\s|$|[^\pL])`)},
+	// a bare "I" is a level only as a suffix, as in "Engineer I" or
+	// "Engineer I, Payments", never as in "Storage I/O Engineer"
+	{Junior, regexp.MustCompile(`\sI\s*(?:$|[,(|–-])`)},
+}
+
+func titleSeniority(title string) string {
+	for _, l := range titleLevels {
+		if l.pattern.MatchString(title) {
+			return l.level
+		}
+	}
+	return ""
+}
+
+// experience matches the years a description asks for, e.g. "5+ years of
+// experience" or "3-5 years' experience".
+var experience = regexp.MustCompile(`(?i)\b(\d{1,2})\+?\s*(?:(?:-|–|to)\s*\d{1,2}\+?\s*)?(?:years?|yrs?)['’]?\s+(?:of\s+)?(?:\w+\s+){0,3}?(?:experience|exp)\b`)
+
+// experienceSeniority guesses a level from the fewest years of experience
+// the description asks for.
+func experienceSeniority(description string) string {
+	fewest := -1
+
+	for _, m := range experience.FindAllStringSubmatch(description, -1) {
+		years, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		if fewest < 0 || years < fewest {
+			fewest = years
+		}
+	}
+
+	switch {
+	case fewest < 0:
+		return ""
+	case fewest < 2:
+		return Junior
+	case fewest < 5:
+		return Mid
+	case fewest < 8:
+		return Senior
+	default:
+		return Staff
+	}
+}
+
+func (t *Tagger) compile() {
+	// names from options are compiled last, so they win over the built-ins
+	var extra []string
+	for tag := range t.options.Synonyms {
+		extra = append(extra, tag)
+	}
+	sort.Strings(extra)
+
+	t.names = map[string]string{}
+
+	var caseless, exact []string
+
+	add := func(tag, name string) {
+		if strings.ToLower(name) == name {
+			t.names[normalize(name)] = tag
+			caseless = append(caseless, name)
+			return
+		}
+		t.names[name] = tag
+		exact = append(exact, name)
+	}
+
+	for tag, names := range technologies {
+		for _, name := range names {
+			add(tag, name)
+		}
+	}
+
+	for _, tag := range extra {
+		if _, ok := technologies[tag]; !ok {
+			add(tag, tag)
+		}
+		for _, name := range t.options.Synonyms[tag] {
+			add(tag, name)
+		}
+	}
+
+	t.caseless = alternation(caseless, "(?i)")
+	t.exact = alternation(exact, "")
+}
+
+// alternation matches the longest name first, so "ruby on rails" beats "ruby".
+func alternation(names []string, flags string) *regexp.Regexp {
+	if len(names) == 0 {
+		return nil
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strings.ReplaceAll(regexp.QuoteMeta(name), ` `, `[\s-]+`)
+	}
+
+	return regexp.MustCompile(flags + `(?:^|[^\pL\pN.#+])(` + strings.Join(quoted, "|") + `)`)
+}
+
+// normalize folds case and the spaces or hyphens between words of a name.
+func normalize(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(name, "-", " "))), " ")
+}
+
+// wordEnd reports whether a name ending at i is not the start of a longer
+// word, so "java" does not match "javascript". A trailing full stop ends a
+// sentence rather than continuing a name like "node.js".
+func wordEnd(text string, i int) bool {
+	if i >= len(text) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	if r == '.' {
+		next, _ := utf8.DecodeRuneInString(text[i+1:])
+		return i+1 >= len(text) || !unicode.IsLetter(next)
+	}
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+}
+
+func New(opts ...Option) *Tagger {
+	options := NewOptions(opts...)
+
+	t := &Tagger{
+		options: options,
+	}
+
+	t.compile()
+
+	return t
+}
@@ -2,90 +2,125 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
-
+	"time"
+	_ "time/tzdata"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/w-h-a/scraper/internal/canonical"
+	"github.com/w-h-a/scraper/internal/clients/cache"
+	"github.com/w-h-a/scraper/internal/clients/enricher"
+	"github.com/w-h-a/scraper/internal/clients/notifier"
+	"github.com/w-h-a/scraper/internal/clients/outbox"
 	"github.com/w-h-a/scraper/internal/clients/readwriter"
 	"github.com/w-h-a/scraper/internal/clients/readwriter/sheets"
+	"github.com/w-h-a/scraper/internal/clients/retry"
 	"github.com/w-h-a/scraper/internal/clients/scraper"
 	"github.com/w-h-a/scraper/internal/clients/scraper/feed"
 	"github.com/w-h-a/scraper/internal/config"
+	"github.com/w-h-a/scraper/internal/services/admin"
 	"github.com/w-h-a/scraper/internal/services/jobhunter"
+	"github.com/w-h-a/scraper/internal/tagging"
 	"go.opentelemetry.io/contrib/bridges/otelslog"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
 	globallog "go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/propagation"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
-func main() {
-	ctx := context.Background()
+const usage = `Usage:
+  scraper [run]          run the job hunter service on its schedule
+  scraper hunt --once    run a single job hunt and exit
+  scraper hunt --dry-run run a single job hunt and print what would be written
+`
 
-	// config
-	config.New()
+func main() {
+	command, args := "run", os.Args[1:]
 
-	// setup resource
-	res, err := resource.New(
-		ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(config.Name()),
-			semconv.DeploymentEnvironment(config.Env()),
-			semconv.ServiceVersion(config.Version()),
-		),
-	)
-	if err != nil {
-		panic(err)
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
 	}
 
-	// setup logger
-	lp, err := initLogger(ctx, res)
-	if err != nil {
-		panic(err)
+	switch command {
+	case "run":
+		run(args)
+	case "hunt":
+		os.Exit(hunt(args))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(exitUsage)
 	}
-	defer lp.Shutdown(ctx)
+}
 
-	logger := otelslog.NewLogger(
-		config.Name(),
-		otelslog.WithLoggerProvider(lp),
-	)
+// run is the long-lived service: scheduled hunts plus the admin server.
+func run(args []string) {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	fs.Parse(args)
 
-	slog.SetDefault(logger)
+	ctx := context.Background()
 
-	// setup tp
-	tp, err := initTracer(ctx, res)
+	// config
+	config.New()
+
+	// setup telemetry
+	tel, err := initTelemetry(ctx, os.Stdout)
 	if err != nil {
 		panic(err)
 	}
-	defer tp.Shutdown(ctx)
+	defer tel.shutdown(ctx)
 
 	// wait group & stop channels
 	var wg sync.WaitGroup
 	stopChannels := map[string]chan struct{}{}
 
 	// setup
-	rw, err := initReadWriter(ctx)
+	canonicalizer, err := initCanonicalizer(ctx)
 	if err != nil {
 		panic(err)
 	}
 
-	s, err := initScraper(ctx)
+	rw, err := initReadWriter(ctx)
 	if err != nil {
 		panic(err)
 	}
 
-	hunter := jobhunter.New(s, rw)
+	hunter, err := initHunter(ctx, rw, canonicalizer, false)
+	if err != nil {
+		panic(err)
+	}
 	stopChannels["hunter"] = make(chan struct{})
 
+	adminOpts := []admin.Option{
+		admin.WithAddress(config.AdminAddress()),
+	}
+
+	if tel.metricsHandler != nil {
+		adminOpts = append(adminOpts, admin.WithHandler("GET /metrics", tel.metricsHandler))
+	}
+
+	adminServer := admin.New(hunter, adminOpts...)
+	stopChannels["admin"] = make(chan struct{})
+
 	// error and sig chans
 	errCh := make(chan error, len(stopChannels))
 	signalCh := make(chan os.Signal, 1)
@@ -99,6 +134,13 @@ func main() {
 		errCh <- hunter.Run(stopChannels["hunter"])
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		slog.InfoContext(ctx, "starting admin server")
+		errCh <- adminServer.Run(stopChannels["admin"])
+	}()
+
 	// block until shutdown
 	select {
 	case err := <-errCh:
@@ -126,7 +168,186 @@ func main() {
 	slog.InfoContext(ctx, "shutdown")
 }
 
-func initLogger(ctx context.Context, res *resource.Resource) (*sdklog.LoggerProvider, error) {
+type telemetry struct {
+	lp             *sdklog.LoggerProvider
+	tp             *sdktrace.TracerProvider
+	mp             *sdkmetric.MeterProvider
+	metricsHandler http.Handler
+}
+
+// shutdown flushes every provider, logs last so that late records still go out.
+func (t *telemetry) shutdown(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if t.mp != nil {
+		t.mp.Shutdown(ctx)
+	}
+
+	if t.tp != nil {
+		t.tp.Shutdown(ctx)
+	}
+
+	if t.lp != nil {
+		t.lp.Shutdown(ctx)
+	}
+}
+
+// initTelemetry sets up logs, traces and metrics. Logs without a collector go
+// to logOut.
+func initTelemetry(ctx context.Context, logOut io.Writer) (*telemetry, error) {
+	tel := &telemetry{}
+
+	// setup resource
+	res, err := resource.New(
+		ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(config.Name()),
+			semconv.DeploymentEnvironment(config.Env()),
+			semconv.ServiceVersion(config.Version()),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// setup logger
+	tel.lp, err = initLogger(ctx, res, logOut)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := otelslog.NewLogger(
+		config.Name(),
+		otelslog.WithLoggerProvider(tel.lp),
+	)
+
+	slog.SetDefault(logger)
+
+	// setup tp
+	tel.tp, err = initTracer(ctx, res)
+	if err != nil {
+		tel.shutdown(ctx)
+		return nil, err
+	}
+
+	// setup mp
+	tel.mp, tel.metricsHandler, err = initMeter(ctx, res)
+	if err != nil {
+		tel.shutdown(ctx)
+		return nil, err
+	}
+
+	return tel, nil
+}
+
+// initHunter builds the job hunter around rw. The feed cache also holds the
+// circuit breaker. A dry run keeps both in memory, so that the next real run
+// still sees every item, and leaves out the notifier and the outbox.
+func initHunter(ctx context.Context, rw readwriter.ReadWriter, canonicalizer *canonical.Canonicalizer, dryRun bool) (*jobhunter.Service, error) {
+	feedCache, err := initFeedCache(ctx, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := initScraper(ctx, feedCache)
+	if err != nil {
+		return nil, err
+	}
+
+	feeds, err := initFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scrapers, err := initFeedScrapers(ctx, feeds, feedCache)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := jobhunter.NewSchedule(
+		config.Schedule(),
+		config.ScheduleTimezone(),
+		config.ScheduleJitter(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []jobhunter.Option{
+		jobhunter.WithFeeds(feeds...),
+		jobhunter.WithScrapers(scrapers),
+		jobhunter.WithSchedule(schedule),
+		jobhunter.WithCanonicalizer(canonicalizer),
+		jobhunter.WithDescriptionLimit(config.DescriptionLimit()),
+		jobhunter.WithCircuitBreaker(
+			config.CircuitBreakerThreshold(),
+			config.CircuitBreakerCooldown(),
+		),
+		jobhunter.WithBreakerCache(feedCache),
+	}
+
+	if len(config.Enricher()) > 0 {
+		e, err := enricher.New(
+			enricher.EnricherType(config.Enricher()),
+			enricher.WithRetry(retry.WithMaxAttempts(config.ScraperMaxAttempts())),
+		)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, jobhunter.WithEnricher(e))
+	}
+
+	if len(config.FiltersPath()) > 0 {
+		rules, err := jobhunter.LoadFilterRules(config.FiltersPath())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, jobhunter.WithFilterRules(rules))
+	}
+
+	if len(config.TagsPath()) > 0 {
+		tagOpts, err := tagging.LoadDictionary(config.TagsPath())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, jobhunter.WithTagger(tagging.New(tagOpts...)))
+	}
+
+	if len(config.DedupMode()) > 0 {
+		mode, ok := jobhunter.DedupModes[config.DedupMode()]
+		if !ok {
+			return nil, fmt.Errorf("unsupported dedup mode %q", config.DedupMode())
+		}
+		opts = append(opts, jobhunter.WithFuzzyDedup(mode, config.DedupThreshold()))
+	}
+
+	if len(config.Notifier()) > 0 && !dryRun {
+		n, err := notifier.New(
+			notifier.NotifierType(config.Notifier()),
+			notifier.WithLocation(config.NotifierLocation()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, jobhunter.WithNotifier(n))
+	}
+
+	if len(config.Outbox()) > 0 && !dryRun {
+		ob, err := outbox.New(
+			outbox.OutboxType(config.Outbox()),
+			outbox.WithLocation(config.OutboxLocation()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, jobhunter.WithOutbox(ob))
+	}
+
+	return jobhunter.New(s, rw, opts...), nil
+}
+
+func initLogger(ctx context.Context, res *resource.Resource, out io.Writer) (*sdklog.LoggerProvider, error) {
 	var exporter sdklog.Exporter
 	var err error
 
@@ -137,7 +358,7 @@ func initLogger(ctx context.Context, res *resource.Resource) (*sdklog.LoggerProv
 			otlploghttp.WithInsecure(),
 		)
 	} else {
-		exporter, err = stdoutlog.New()
+		exporter, err = stdoutlog.New(stdoutlog.WithWriter(out))
 	}
 
 	if err != nil {
@@ -185,13 +406,112 @@ func initTracer(ctx context.Context, res *resource.Resource) (*sdktrace.TracerPr
 	return tracerProvider, nil
 }
 
-func initReadWriter(_ context.Context) (readwriter.ReadWriter, error) {
-	return sheets.NewReadWriter(
+func initMeter(ctx context.Context, res *resource.Resource) (*sdkmetric.MeterProvider, http.Handler, error) {
+	opts := []sdkmetric.Option{
+		sdkmetric.WithResource(res),
+	}
+
+	if len(config.MetricsAddress()) > 0 {
+		exporter, err := otlpmetrichttp.New(
+			ctx,
+			otlpmetrichttp.WithEndpoint(config.MetricsAddress()),
+			otlpmetrichttp.WithInsecure(),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create exporter for metrics: %v", err)
+		}
+
+		opts = append(opts, sdkmetric.WithReader(
+			sdkmetric.NewPeriodicReader(exporter),
+		))
+	}
+
+	var handler http.Handler
+
+	if config.MetricsPrometheus() {
+		registry := prometheus.NewRegistry()
+
+		exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create prometheus exporter for metrics: %v", err)
+		}
+
+		opts = append(opts, sdkmetric.WithReader(exporter))
+
+		handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+
+	meterProvider := sdkmetric.NewMeterProvider(opts...)
+
+	otel.SetMeterProvider(meterProvider)
+
+	return meterProvider, handler, nil
+}
+
+func initCanonicalizer(_ context.Context) (*canonical.Canonicalizer, error) {
+	if len(config.CanonicalRulesPath()) == 0 {
+		return canonical.New(), nil
+	}
+
+	opts, err := canonical.LoadRules(config.CanonicalRulesPath())
+	if err != nil {
+		return nil, err
+	}
+
+	return canonical.New(opts...), nil
+}
+
+func initReadWriter(_ context.Context, opts ...readwriter.Option) (readwriter.ReadWriter, error) {
+	opts = append([]readwriter.Option{
 		readwriter.WithLocation(config.ReadWriterLocation()),
 		sheets.WithServiceAccountKeyPath(config.SheetsServiceAccountPath()),
-	), nil
+		sheets.WithChunkSize(config.SheetsChunkSize()),
+	}, opts...)
+
+	return readwriter.New(readwriter.ReadWriterType(config.ReadWriter()), opts...)
+}
+
+func initFeedCache(_ context.Context, dryRun bool) (cache.Cache, error) {
+	if dryRun {
+		return cache.New(cache.Memory)
+	}
+
+	return cache.New(
+		cache.CacheType(config.FeedCache()),
+		cache.WithLocation(config.FeedCacheLocation()),
+	)
+}
+
+func initScraper(_ context.Context, feedCache cache.Cache) (scraper.Scraper, error) {
+	return scraper.New(
+		scraper.ScraperType(config.Scraper()),
+		scraper.WithRetry(retry.WithMaxAttempts(config.ScraperMaxAttempts())),
+		feed.WithCache(feedCache),
+	)
 }
 
-func initScraper(_ context.Context) (scraper.Scraper, error) {
-	return feed.NewScraper(), nil
+func initFeedScrapers(_ context.Context, feeds []jobhunter.Feed, feedCache cache.Cache) (map[scraper.ScraperType]scraper.Scraper, error) {
+	scrapers := map[scraper.ScraperType]scraper.Scraper{}
+
+	for _, t := range jobhunter.ScraperTypes(feeds) {
+		sc, err := scraper.New(
+			t,
+			scraper.WithRetry(retry.WithMaxAttempts(config.ScraperMaxAttempts())),
+			feed.WithCache(feedCache),
+		)
+		if err != nil {
+			return nil, err
+		}
+		scrapers[t] = sc
+	}
+
+	return scrapers, nil
+}
+
+func initFeeds(_ context.Context) ([]jobhunter.Feed, error) {
+	if len(config.FeedsPath()) == 0 {
+		return jobhunter.DefaultFeeds(), nil
+	}
+
+	return jobhunter.LoadFeeds(config.FeedsPath())
 }
@@ -0,0 +1,22 @@
+package main
+
+// Implementations register themselves with their client package on import.
+// New backends only need to be added here to become selectable via config.
+import (
+	_ "github.com/w-h-a/scraper/internal/clients/cache/file"
+	_ "github.com/w-h-a/scraper/internal/clients/cache/memory"
+	_ "github.com/w-h-a/scraper/internal/clients/enricher/jsonld"
+	_ "github.com/w-h-a/scraper/internal/clients/enricher/mock"
+	_ "github.com/w-h-a/scraper/internal/clients/notifier/mock"
+	_ "github.com/w-h-a/scraper/internal/clients/notifier/slack"
+	_ "github.com/w-h-a/scraper/internal/clients/outbox/file"
+	_ "github.com/w-h-a/scraper/internal/clients/outbox/mock"
+	_ "github.com/w-h-a/scraper/internal/clients/readwriter/mock"
+	_ "github.com/w-h-a/scraper/internal/clients/readwriter/postgres"
+	_ "github.com/w-h-a/scraper/internal/clients/readwriter/sheets"
+	_ "github.com/w-h-a/scraper/internal/clients/readwriter/sqlite"
+	_ "github.com/w-h-a/scraper/internal/clients/scraper/feed"
+	_ "github.com/w-h-a/scraper/internal/clients/scraper/greenhouse"
+	_ "github.com/w-h-a/scraper/internal/clients/scraper/lever"
+	_ "github.com/w-h-a/scraper/internal/clients/scraper/mock"
+)
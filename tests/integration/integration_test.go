@@ -3,13 +3,16 @@ package integration
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/w-h-a/scraper/internal/clients/reader"
 	"github.com/w-h-a/scraper/internal/clients/readwriter"
+	"github.com/w-h-a/scraper/internal/clients/readwriter/postgres"
 	"github.com/w-h-a/scraper/internal/clients/readwriter/sheets"
 	"github.com/w-h-a/scraper/internal/clients/scraper/feed"
 	"github.com/w-h-a/scraper/internal/services/jobhunter"
@@ -37,7 +40,7 @@ func TestIntegration_ReadWriter_CanReadAndWrite(t *testing.T) {
 	}
 
 	t.Run("AppendData", func(t *testing.T) {
-		err := rw.WriteBatch(ctx, testRow)
+		_, err := rw.WriteBatch(ctx, testRow)
 		require.NoError(t, err)
 	})
 
@@ -71,7 +74,7 @@ func TestSystem_FullJobHuntCycle(t *testing.T) {
 
 	realReadWriter.ClearBatch(ctx)
 
-	service := jobhunter.New(realScraper, realReadWriter)
+	service := jobhunter.New(realScraper, realReadWriter, jobhunter.WithFeeds(jobhunter.DefaultFeeds()...))
 
 	// 2. Act
 	err := service.ExecuteJobHunt(ctx)
@@ -89,3 +92,58 @@ func TestSystem_FullJobHuntCycle(t *testing.T) {
 	require.NoError(t, err)
 	require.Greater(t, len(finalLinks), 0)
 }
+
+func TestIntegration_Postgres_CanReadAndWrite(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) == 0 || len(os.Getenv("POSTGRES_LOCATION")) == 0 {
+		t.Log("SKIPPING INTEGRATION TEST")
+		return
+	}
+
+	ctx := context.Background()
+
+	first := postgres.NewReadWriter(
+		readwriter.WithLocation(os.Getenv("POSTGRES_LOCATION")),
+	)
+	t.Cleanup(func() { first.(io.Closer).Close() })
+
+	// migrations already applied are skipped
+	rw := postgres.NewReadWriter(
+		readwriter.WithLocation(os.Getenv("POSTGRES_LOCATION")),
+	)
+	t.Cleanup(func() { rw.(io.Closer).Close() })
+
+	require.NoError(t, rw.ClearBatch(ctx))
+
+	uniqueID := fmt.Sprintf("http://test.link.com/%d", time.Now().UnixNano())
+	mergedID := uniqueID + "/merged"
+
+	testRow := make([]any, len(jobhunter.Columns))
+	copy(testRow, []any{time.Now().Format("2006-01-02 15:04:05"), "TEST_INTEGRATION", "TEST JOB TITLE", uniqueID, "Test Description", "TEST"})
+	testRow[slices.Index(jobhunter.Columns, "also_listed_at")] = mergedID
+
+	t.Run("AppendData", func(t *testing.T) {
+		result, err := rw.WriteBatch(ctx, [][]any{testRow})
+		require.NoError(t, err)
+		require.Empty(t, result.Skipped)
+	})
+
+	t.Run("ConflictingLinksAreSkipped", func(t *testing.T) {
+		result, err := rw.WriteBatch(ctx, [][]any{testRow, testRow})
+		require.NoError(t, err)
+		require.Equal(t, []string{uniqueID, uniqueID}, result.Skipped)
+	})
+
+	t.Run("ReadExistingOnlyMergedLinks", func(t *testing.T) {
+		links, err := rw.ReadExisting(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{mergedID: true}, links)
+	})
+
+	t.Run("LookupLinks", func(t *testing.T) {
+		links, err := rw.(reader.LinkLooker).LookupLinks(ctx, []string{uniqueID, uniqueID + "/unknown"})
+
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{uniqueID: true}, links)
+	})
+}